@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/Tarick/naca-items/internal/logger/zaplogger"
+	"github.com/Tarick/naca-items/internal/processor"
+	"github.com/Tarick/naca-items/internal/processor/stages"
 	"github.com/Tarick/naca-items/internal/tracing"
 
+	"github.com/Tarick/naca-items/internal/application/health"
+	"github.com/Tarick/naca-items/internal/application/lifecycle"
 	"github.com/Tarick/naca-items/internal/application/server"
+	"github.com/Tarick/naca-items/internal/graph/resolver"
+	"github.com/Tarick/naca-items/internal/repository/memory"
 	"github.com/Tarick/naca-items/internal/repository/postgresql"
 	"github.com/Tarick/naca-items/internal/version"
 
@@ -16,7 +23,10 @@ import (
 )
 
 func main() {
-	var cfgFile string
+	var (
+		cfgFile  string
+		inMemory bool
+	)
 
 	// rootCmd represents the base command when called without any subcommands
 	rootCmd := &cobra.Command{
@@ -24,10 +34,11 @@ func main() {
 		Short: "RSS Feeds API",
 		Long:  `RSS Feeds API`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return startServer(cfgFile)
+			return startServer(cfgFile, inMemory)
 		},
 	}
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ./config.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&inMemory, "in-memory", false, "use in-memory items repository instead of database, for local development (data is lost on exit)")
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print the version number of application",
@@ -43,7 +54,7 @@ func main() {
 	}
 }
 
-func startServer(cfgFile string) error {
+func startServer(cfgFile string, inMemory bool) error {
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -67,29 +78,57 @@ func startServer(cfgFile string) error {
 	logger := zaplogger.New(logCfg).Sugar()
 	defer logger.Sync()
 
+	// Init lifecycle, components are stopped in reverse order of registration
+	lifecycleCfg := lifecycle.Config{}
+	if err := viper.UnmarshalKey("lifecycle", &lifecycleCfg); err != nil {
+		return fmt.Errorf("Failure reading 'lifecycle' configuration, %v", err)
+	}
+	lc := lifecycle.New(lifecycleCfg, logger)
+	defer lc.Shutdown()
+
 	// Init tracing
 	tracingCfg := tracing.Config{}
 	if err := viper.UnmarshalKey("tracing", &tracingCfg); err != nil {
 		return fmt.Errorf("Failure reading 'tracing' configuration, %v", err)
 	}
 	tracer, tracerCloser, err := tracing.New(tracingCfg, tracing.NewZapLogger(logger))
-	defer tracerCloser.Close()
 	if err != nil {
 		return fmt.Errorf("FATAL: Cannot init tracing, %v", err)
 	}
+	lc.OnStop("tracer", func(context.Context) error { return tracerCloser.Close() })
 
-	// Create db configuration
-	databaseViperConfig := viper.Sub("database")
-	dbCfg := &postgresql.Config{}
-	if err := databaseViperConfig.UnmarshalExact(dbCfg); err != nil {
-		fmt.Println("FATAL: failure reading 'database' configuration: ", err)
-		os.Exit(1)
-	}
-	// Open db
-	itemsRepository, err := postgresql.New(dbCfg, postgresql.NewZapLogger(logger.Desugar()), tracer)
-	if err != nil {
-		fmt.Println("FATAL: failure creating database connection for Items, ", err)
-		os.Exit(1)
+	readiness := health.New(logger)
+	var (
+		itemsRepository    resolver.ItemsRepository
+		memoryRepository   *memory.Repository
+		itemEventsListener *postgresql.ItemEventsListener
+	)
+	if inMemory {
+		logger.Warn("Using in-memory items repository, data will be lost on exit")
+		memoryRepository = memory.New()
+		itemsRepository = memoryRepository
+	} else {
+		// Create db configuration
+		databaseViperConfig := viper.Sub("database")
+		dbCfg := &postgresql.Config{}
+		if err := databaseViperConfig.UnmarshalExact(dbCfg); err != nil {
+			fmt.Println("FATAL: failure reading 'database' configuration: ", err)
+			os.Exit(1)
+		}
+		// Open db
+		repository, err := postgresql.New(dbCfg, postgresql.NewZapLogger(logger.Desugar()), tracer)
+		if err != nil {
+			fmt.Println("FATAL: failure creating database connection for Items, ", err)
+			os.Exit(1)
+		}
+		lc.OnStop("database", func(context.Context) error {
+			repository.Close()
+			return nil
+		})
+		readiness.Register("postgres", health.CheckerFunc(repository.Healthcheck))
+		readiness.Register("migrations", health.CheckerFunc(repository.CheckSchemaVersion))
+		itemsRepository = repository
+		itemEventsListener = repository.NewItemEventsListener(logger)
 	}
 
 	// Create web server
@@ -99,7 +138,52 @@ func startServer(cfgFile string) error {
 		fmt.Println("FATAL: failure reading 'server' configuration, ", err)
 		os.Exit(1)
 	}
-	handler := server.NewHandler(logger, tracer, itemsRepository)
-	srv := server.New(serverCfg, logger, handler)
-	return srv.StartAndServe()
+	// Items created by API are identified the same way as by worker
+	canonicalizationCfg := processor.NewURLCanonicalizationConfig()
+	if err := viper.UnmarshalKey("url_canonicalization", &canonicalizationCfg); err != nil {
+		return fmt.Errorf("FATAL: failure reading 'url_canonicalization' configuration: %v", err)
+	}
+	identityCfg := processor.IdentityConfig{}
+	if err := viper.UnmarshalKey("identity", &identityCfg); err != nil {
+		return fmt.Errorf("FATAL: failure reading 'identity' configuration: %v", err)
+	}
+	urlCanonicalizer := processor.NewURLCanonicalizer(canonicalizationCfg)
+	identities, err := processor.NewIdentityStrategies(identityCfg, urlCanonicalizer)
+	if err != nil {
+		return fmt.Errorf("FATAL: failure creating item identity strategies: %v", err)
+	}
+	// Items created by API are passed through the same processing stages as by worker
+	processingCfg := stages.Config{}
+	if err := viper.UnmarshalKey("processing", &processingCfg); err != nil {
+		return fmt.Errorf("FATAL: failure reading 'processing' configuration: %v", err)
+	}
+	processingStages, err := stages.New(processingCfg, urlCanonicalizer)
+	if err != nil {
+		return fmt.Errorf("FATAL: failure creating processing stages: %v", err)
+	}
+	handler, err := server.NewHandler(serverCfg.GraphQL, logger, tracer, itemsRepository, identities, processingStages)
+	if err != nil {
+		return fmt.Errorf("FATAL: failure creating GraphQL handler, %v", err)
+	}
+	if memoryRepository != nil {
+		memoryRepository.Subscribe(handler.HandleItemEvent)
+	}
+	if itemEventsListener != nil {
+		// Items changes made by worker and other API instances are received from database
+		itemEventsListener.Subscribe(handler.HandleItemEvent)
+		listenCtx, stopListening := context.WithCancel(context.Background())
+		lc.Go("item events listener", func() error { return itemEventsListener.Run(listenCtx) })
+		lc.OnStop("item events listener", func(context.Context) error {
+			stopListening()
+			return nil
+		})
+	}
+	srv := server.New(serverCfg, logger, handler, readiness)
+	lc.OnStop("subscriptions", func(context.Context) error {
+		handler.CloseSubscriptions()
+		return nil
+	})
+	lc.Go("server", srv.StartAndServe)
+	lc.OnStop("server", srv.Shutdown)
+	return lc.Wait()
 }
@@ -1,18 +1,31 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/Tarick/naca-items/internal/application/admin"
+	"github.com/Tarick/naca-items/internal/application/health"
+	"github.com/Tarick/naca-items/internal/application/lifecycle"
+	"github.com/Tarick/naca-items/internal/application/remap"
 	"github.com/Tarick/naca-items/internal/application/worker"
+	"github.com/Tarick/naca-items/internal/entity"
 	"github.com/Tarick/naca-items/internal/logger/zaplogger"
 	"github.com/Tarick/naca-items/internal/messaging/nsqclient/consumer"
+	"github.com/Tarick/naca-items/internal/messaging/nsqclient/producer"
+	"github.com/Tarick/naca-items/internal/outbox"
 	"github.com/Tarick/naca-items/internal/processor"
+	"github.com/Tarick/naca-items/internal/processor/stages"
 	"github.com/Tarick/naca-items/internal/tracing"
 
 	"github.com/Tarick/naca-items/internal/repository/postgresql"
 	"github.com/Tarick/naca-items/internal/version"
 
+	"github.com/gofrs/uuid"
+	"github.com/opentracing/opentracing-go"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -40,8 +53,31 @@ func main() {
 		},
 	}
 
+	remapOptions := remap.Options{}
+	var remapPublications []string
+	remapCmd := &cobra.Command{
+		Use:   "remap-uuids",
+		Short: "Recompute UUIDs of stored items with configured identity strategies",
+		Long: `Recomputes UUIDs of stored items using configured identity strategies and remaps items to new UUIDs.
+Run it after identity strategy of publication is changed, with worker stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, publication := range remapPublications {
+				publicationUUID, err := uuid.FromString(publication)
+				if err != nil {
+					return fmt.Errorf("invalid publication UUID %s: %v", publication, err)
+				}
+				remapOptions.Publications = append(remapOptions.Publications, publicationUUID)
+			}
+			return remapUUIDs(cfgFile, remapOptions)
+		},
+	}
+	remapCmd.Flags().StringSliceVar(&remapPublications, "publication", nil, "UUID of publication to remap, could be repeated (default is all publications)")
+	remapCmd.Flags().BoolVar(&remapOptions.DryRun, "dry-run", false, "report changes without applying them")
+	remapCmd.Flags().BoolVar(&remapOptions.DeleteDuplicates, "delete-duplicates", false, "delete items, which new UUID is taken by another item")
+
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ./config.yaml)")
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(remapCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -49,7 +85,7 @@ func main() {
 	}
 }
 
-func startWorker(cfgFile string) error {
+func readConfig(cfgFile string) error {
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -62,6 +98,34 @@ func startWorker(cfgFile string) error {
 		return fmt.Errorf("FATAL: error in config file %s. %v", viper.ConfigFileUsed(), err)
 	}
 	fmt.Println("Using config file:", viper.ConfigFileUsed())
+	return nil
+}
+
+// readURLCanonicalizer creates URL canonicalizer with configured rules, default rules are used if they are not configured
+func readURLCanonicalizer() (*entity.URLCanonicalizer, error) {
+	canonicalizationCfg := processor.NewURLCanonicalizationConfig()
+	if err := viper.UnmarshalKey("url_canonicalization", &canonicalizationCfg); err != nil {
+		return nil, fmt.Errorf("FATAL: failure reading 'url_canonicalization' configuration: %v", err)
+	}
+	return processor.NewURLCanonicalizer(canonicalizationCfg), nil
+}
+
+func readIdentityStrategies(canonicalizer *entity.URLCanonicalizer) (*entity.IdentityStrategies, error) {
+	identityCfg := processor.IdentityConfig{}
+	if err := viper.UnmarshalKey("identity", &identityCfg); err != nil {
+		return nil, fmt.Errorf("FATAL: failure reading 'identity' configuration: %v", err)
+	}
+	identities, err := processor.NewIdentityStrategies(identityCfg, canonicalizer)
+	if err != nil {
+		return nil, fmt.Errorf("FATAL: failure creating item identity strategies: %v", err)
+	}
+	return identities, nil
+}
+
+func startWorker(cfgFile string) error {
+	if err := readConfig(cfgFile); err != nil {
+		return err
+	}
 	// Init logging
 	logCfg := &zaplogger.Config{}
 	if err := viper.UnmarshalKey("logging", logCfg); err != nil {
@@ -70,16 +134,24 @@ func startWorker(cfgFile string) error {
 	logger := zaplogger.New(logCfg).Sugar()
 	defer logger.Sync()
 
+	// Init lifecycle, components are stopped in reverse order of registration
+	lifecycleCfg := lifecycle.Config{}
+	if err := viper.UnmarshalKey("lifecycle", &lifecycleCfg); err != nil {
+		return fmt.Errorf("FATAL: Failure reading 'lifecycle' configuration, %v", err)
+	}
+	lc := lifecycle.New(lifecycleCfg, logger)
+	defer lc.Shutdown()
+
 	// Init tracing
 	tracingCfg := tracing.Config{}
 	if err := viper.UnmarshalKey("tracing", &tracingCfg); err != nil {
 		return fmt.Errorf("FATAL: Failure reading 'tracing' configuration, %v", err)
 	}
 	tracer, tracerCloser, err := tracing.New(tracingCfg, tracing.NewZapLogger(logger))
-	defer tracerCloser.Close()
 	if err != nil {
 		return fmt.Errorf("FATAL: Cannot init tracing, %v", err)
 	}
+	lc.OnStop("tracer", func(context.Context) error { return tracerCloser.Close() })
 
 	// Create db configuration
 	databaseViperConfig := viper.Sub("database")
@@ -92,18 +164,142 @@ func startWorker(cfgFile string) error {
 	if err != nil {
 		return fmt.Errorf("FATAL: failure creating database connection, %v", err)
 	}
+	lc.OnStop("database", func(context.Context) error {
+		repository.Close()
+		return nil
+	})
 
 	consumeViperConfig := viper.Sub("consume")
 	consumeCfg := &consumer.MessageConsumerConfig{}
 	if err := consumeViperConfig.UnmarshalExact(&consumeCfg); err != nil {
 		return fmt.Errorf("FATAL: failure reading 'consume' configuration: %v", err)
 	}
+	// Items are passed through configured processing stages before they are stored
+	processingViperConfig := viper.Sub("processing")
+	processingCfg := stages.Config{}
+	if err := processingViperConfig.UnmarshalExact(&processingCfg); err != nil {
+		return fmt.Errorf("FATAL: failure reading 'processing' configuration: %v", err)
+	}
+	// URL canonicalization rules are shared by processing stage and canonical_url identity strategy
+	urlCanonicalizer, err := readURLCanonicalizer()
+	if err != nil {
+		return err
+	}
+	processingStages, err := stages.New(processingCfg, urlCanonicalizer)
+	if err != nil {
+		return fmt.Errorf("FATAL: failure creating processing stages: %v", err)
+	}
+	identities, err := readIdentityStrategies(urlCanonicalizer)
+	if err != nil {
+		return err
+	}
 	// Construct consumer with message handler
-	processor := processor.New(repository, logger, tracer)
+	processor := processor.New(repository, logger, tracer, identities, processingStages...)
 	consumer, err := consumer.New(consumeCfg, processor, logger)
 	if err != nil {
 		return fmt.Errorf("FATAL: consumer creation failed, %v", err)
 	}
+	// Metrics, health and profiling endpoints are served on admin port, it is started before and stopped after worker
+	adminViperConfig := viper.Sub("admin")
+	adminCfg := admin.Config{}
+	if err := adminViperConfig.UnmarshalExact(&adminCfg); err != nil {
+		return fmt.Errorf("FATAL: failure reading 'admin' configuration: %v", err)
+	}
+	readiness := health.New(logger)
+	readiness.Register("postgres", health.CheckerFunc(repository.Healthcheck))
+	readiness.Register("migrations", health.CheckerFunc(repository.CheckSchemaVersion))
+	readiness.Register("nsqlookupd", health.HTTPChecker(&http.Client{Timeout: 2 * time.Second}, "http://"+consumeCfg.NSQLookup+"/ping"))
+	adminSrv := admin.New(adminCfg, logger, readiness)
+	lc.Go("admin server", adminSrv.StartAndServe)
+	lc.OnStop("admin server", adminSrv.Shutdown)
+
 	wrkr := worker.New(consumer, logger)
-	return wrkr.Start()
+	if err := wrkr.Start(); err != nil {
+		return fmt.Errorf("FATAL: worker start failed, %v", err)
+	}
+	// Stopping worker drains in-flight messages and stops NSQ consumer and producers
+	lc.OnStop("worker", func(context.Context) error {
+		wrkr.Stop()
+		return nil
+	})
+
+	// Relay publishes item events, stored by repository in outbox, to NSQ
+	outboxViperConfig := viper.Sub("outbox")
+	outboxCfg := outbox.Config{}
+	if err := outboxViperConfig.UnmarshalExact(&outboxCfg); err != nil {
+		return fmt.Errorf("FATAL: failure reading 'outbox' configuration: %v", err)
+	}
+	eventsProducer, err := producer.New(&producer.MessageProducerConfig{Host: outboxCfg.Host, Topic: outboxCfg.Topic})
+	if err != nil {
+		return fmt.Errorf("FATAL: outbox producer creation failed, %v", err)
+	}
+	lc.OnStop("outbox producer", func(context.Context) error {
+		eventsProducer.Stop()
+		return nil
+	})
+	relay := outbox.New(outboxCfg, repository, eventsProducer, logger)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	lc.Go("outbox relay", func() error {
+		defer close(relayDone)
+		return relay.Run(relayCtx)
+	})
+	// Relay finishes publishing current batch before producer is stopped
+	lc.OnStop("outbox relay", func(ctx context.Context) error {
+		stopRelay()
+		select {
+		case <-relayDone:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	return lc.Wait()
+}
+
+// remapUUIDs changes UUIDs of stored items to the ones, derived by configured identity strategies
+func remapUUIDs(cfgFile string, options remap.Options) error {
+	if err := readConfig(cfgFile); err != nil {
+		return err
+	}
+	logCfg := &zaplogger.Config{}
+	if err := viper.UnmarshalKey("logging", logCfg); err != nil {
+		return fmt.Errorf("Failure reading 'logging' configuration: %v", err)
+	}
+	logger := zaplogger.New(logCfg).Sugar()
+	defer logger.Sync()
+
+	urlCanonicalizer, err := readURLCanonicalizer()
+	if err != nil {
+		return err
+	}
+	identities, err := readIdentityStrategies(urlCanonicalizer)
+	if err != nil {
+		return err
+	}
+	databaseViperConfig := viper.Sub("database")
+	dbCfg := &postgresql.Config{}
+	if err := databaseViperConfig.UnmarshalExact(dbCfg); err != nil {
+		return fmt.Errorf("FATAL: failure reading 'database' configuration: %v", err)
+	}
+	// One-off command isn't traced
+	repository, err := postgresql.New(dbCfg, postgresql.NewZapLogger(logger.Desugar()), opentracing.NoopTracer{})
+	if err != nil {
+		return fmt.Errorf("FATAL: failure creating database connection, %v", err)
+	}
+	defer repository.Close()
+	if err := repository.CheckSchemaVersion(context.Background()); err != nil {
+		return fmt.Errorf("FATAL: %v", err)
+	}
+	if options.DryRun {
+		logger.Info("Dry run, items are not changed")
+	}
+	result, err := remap.New(repository, identities, logger).Run(context.Background(), options)
+	if result != nil {
+		logger.Infof("Checked %d items, remapped %d, found %d duplicates, deleted %d", result.Checked, result.Remapped, result.Duplicates, result.Deleted)
+	}
+	if err != nil {
+		return fmt.Errorf("FATAL: remapping failed, %v", err)
+	}
+	return nil
 }
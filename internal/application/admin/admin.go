@@ -0,0 +1,56 @@
+// Package admin provides HTTP server for operational endpoints of applications without public HTTP API
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Tarick/naca-items/internal/application/health"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Config defines admin server configuration
+type Config struct {
+	Address string `mapstructure:"address"`
+	// Pprof enables runtime profiling endpoints under /debug/pprof/
+	Pprof bool `mapstructure:"pprof"`
+}
+
+// Server serves Prometheus metrics, health and profiling endpoints
+type Server struct {
+	httpServer *http.Server
+	logger     Logger
+}
+
+// New creates admin server
+func New(config Config, logger Logger, health *health.Health) *Server {
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Get("/livez", health.Livez)
+	r.Get("/readyz", health.Readyz)
+	r.Handle("/metrics", promhttp.Handler())
+	if config.Pprof {
+		r.Mount("/debug", middleware.Profiler())
+	}
+	return &Server{
+		httpServer: &http.Server{Addr: config.Address, Handler: r},
+		logger:     logger,
+	}
+}
+
+// StartAndServe starts http server
+func (s *Server) StartAndServe() error {
+	s.logger.Info("Admin server is ready to serve on ", s.httpServer.Addr)
+	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting new connections and waits for in-flight requests to finish until context is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("Admin server is shutting down")
+	return s.httpServer.Shutdown(ctx)
+}
@@ -0,0 +1,141 @@
+// Package health provides liveness and readiness HTTP endpoints with pluggable dependency checks
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// checkTimeout limits duration of all readiness checks
+const checkTimeout = 5 * time.Second
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Checker checks availability of dependency
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc allows to use ordinary function as Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f(ctx)
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type namedChecker struct {
+	name    string
+	checker Checker
+}
+
+// Health serves liveness and readiness endpoints
+type Health struct {
+	logger   Logger
+	checkers []namedChecker
+}
+
+// New creates Health without checkers, register them with Register
+func New(logger Logger) *Health {
+	return &Health{logger: logger}
+}
+
+// Register adds named checker to readiness checks
+func (h *Health) Register(name string, checker Checker) {
+	h.checkers = append(h.checkers, namedChecker{name: name, checker: checker})
+}
+
+// CheckResult is the result of single readiness check
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is readiness response
+type Report struct {
+	Status string                  `json:"status"`
+	Checks map[string]*CheckResult `json:"checks,omitempty"`
+}
+
+// Livez reports that process is running and able to serve HTTP, it doesn't check dependencies
+func (h *Health) Livez(w http.ResponseWriter, r *http.Request) {
+	h.writeReport(w, &Report{Status: statusOK})
+}
+
+// Readyz runs all checks concurrently and reports failure if any of them fails
+func (h *Health) Readyz(w http.ResponseWriter, r *http.Request) {
+	h.writeReport(w, h.Check(r.Context()))
+}
+
+// Check runs all registered checks concurrently
+func (h *Health) Check(ctx context.Context) *Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	report := &Report{Status: statusOK, Checks: make(map[string]*CheckResult, len(h.checkers))}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, c := range h.checkers {
+		wg.Add(1)
+		go func(c namedChecker) {
+			defer wg.Done()
+			start := time.Now()
+			err := c.checker.Check(ctx)
+			result := &CheckResult{Status: statusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+			if err != nil {
+				h.logger.Error("Readiness check ", c.name, " failed: ", err)
+				result.Status = statusFail
+				result.Error = err.Error()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.name] = result
+			if err != nil {
+				report.Status = statusFail
+			}
+		}(c)
+	}
+	wg.Wait()
+	return report
+}
+
+func (h *Health) writeReport(w http.ResponseWriter, report *Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != statusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.logger.Error("Failure writing health report: ", err)
+	}
+}
+
+// HTTPChecker checks that GET request to url returns 200 OK
+func HTTPChecker(client *http.Client, url string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+		}
+		return nil
+	})
+}
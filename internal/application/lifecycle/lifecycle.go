@@ -0,0 +1,138 @@
+// Package lifecycle runs application components and stops them gracefully on termination signal
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// defaultShutdownTimeout is used when shutdown timeout is not configured
+const defaultShutdownTimeout = 30 * time.Second
+
+// Config defines lifecycle configuration
+type Config struct {
+	// ShutdownTimeout is the deadline in seconds for all components to stop
+	ShutdownTimeout int `mapstructure:"shutdown_timeout"`
+}
+
+type runner struct {
+	name string
+	run  func() error
+}
+
+type stopper struct {
+	name string
+	stop func(context.Context) error
+}
+
+// Manager runs long running components and stops registered components in order on SIGINT/SIGTERM
+type Manager struct {
+	logger          Logger
+	shutdownTimeout time.Duration
+	runners         []runner
+	stoppers        []stopper
+	shutdownOnce    sync.Once
+	shutdownErr     error
+}
+
+// New creates lifecycle manager
+func New(config Config, logger Logger) *Manager {
+	shutdownTimeout := time.Duration(config.ShutdownTimeout) * time.Second
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	return &Manager{logger: logger, shutdownTimeout: shutdownTimeout}
+}
+
+// Go registers long running component, e.g. server, to run in background with Wait.
+// Component exit before termination signal triggers shutdown.
+func (m *Manager) Go(name string, run func() error) {
+	m.runners = append(m.runners, runner{name: name, run: run})
+}
+
+// OnStop registers function to stop component. Functions are called sequentially in reverse registration order,
+// like deferred calls, so components should be registered right after creation, dependencies first.
+// Function should drain in-flight work, but return when context is done.
+func (m *Manager) OnStop(name string, stop func(context.Context) error) {
+	m.stoppers = append(m.stoppers, stopper{name: name, stop: stop})
+}
+
+// Wait starts registered runners and blocks until termination signal or runner exit, then stops all components.
+// Returns the runner failure or the first stop failure.
+func (m *Manager) Wait() error {
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+
+	exited := make(chan error, len(m.runners))
+	for _, r := range m.runners {
+		go func(r runner) {
+			if err := r.run(); err != nil {
+				exited <- fmt.Errorf("%s failed: %w", r.name, err)
+				return
+			}
+			exited <- fmt.Errorf("%s exited", r.name)
+		}(r)
+	}
+	m.logger.Info("Started, terminate with 'kill <pid>'")
+
+	var runErr error
+	select {
+	case sig := <-signalChan:
+		m.logger.Info("Received signal ", sig, ", shutting down")
+	case runErr = <-exited:
+		m.logger.Error("Shutting down: ", runErr)
+	}
+	if err := m.Shutdown(); err != nil && runErr == nil {
+		return err
+	}
+	return runErr
+}
+
+// Shutdown calls stop functions sequentially in reverse registration order within shutdown timeout.
+// Function, which doesn't return by the deadline, is logged and waited for, so components never stop out of order.
+// After the deadline remaining functions are still called with expired context to release resources.
+// Shutdown is called by Wait, it is safe to defer it to release resources on startup failure, stop functions run once.
+func (m *Manager) Shutdown() error {
+	m.shutdownOnce.Do(func() {
+		m.shutdownErr = m.shutdown()
+	})
+	return m.shutdownErr
+}
+
+func (m *Manager) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+	var firstErr error
+	for i := len(m.stoppers) - 1; i >= 0; i-- {
+		s := m.stoppers[i]
+		m.logger.Info("Stopping ", s.name)
+		done := make(chan error, 1)
+		go func(s stopper) {
+			done <- s.stop(ctx)
+		}(s)
+		var err error
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			m.logger.Warn("Stopping ", s.name, " exceeded shutdown deadline, waiting for it to return")
+			if err = <-done; err == nil {
+				err = fmt.Errorf("shutdown deadline exceeded")
+			}
+		}
+		if err != nil {
+			m.logger.Error("Failure stopping ", s.name, ": ", err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failure stopping %s: %w", s.name, err)
+			}
+			continue
+		}
+		m.logger.Info("Stopped ", s.name)
+	}
+	return firstErr
+}
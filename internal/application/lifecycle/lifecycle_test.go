@@ -0,0 +1,58 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debug(args ...interface{}) {}
+func (testLogger) Info(args ...interface{})  {}
+func (testLogger) Warn(args ...interface{})  {}
+func (testLogger) Error(args ...interface{}) {}
+func (testLogger) Fatal(args ...interface{}) {}
+
+func TestShutdownOrder(t *testing.T) {
+	m := New(Config{}, testLogger{})
+	m.shutdownTimeout = 50 * time.Millisecond
+	var (
+		mu      sync.Mutex
+		stopped []string
+	)
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = append(stopped, name)
+	}
+	m.OnStop("database", func(context.Context) error {
+		record("database")
+		return nil
+	})
+	// Stopper ignores deadline, the next ones wait for it
+	m.OnStop("consumer", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		record("consumer")
+		return nil
+	})
+	m.OnStop("server", func(context.Context) error {
+		record("server")
+		return errors.New("failure")
+	})
+
+	err := m.Shutdown()
+	if err == nil {
+		t.Error("Shutdown() returned no error of failed stopper")
+	}
+	if want := []string{"server", "consumer", "database"}; !reflect.DeepEqual(stopped, want) {
+		t.Errorf("stopped %v, want %v", stopped, want)
+	}
+	if second := m.Shutdown(); second != err {
+		t.Errorf("second Shutdown() = %v, want %v", second, err)
+	}
+}
@@ -0,0 +1,144 @@
+// Package remap recomputes UUIDs of stored items, after identity strategy of publication is changed
+package remap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+// Repository reads and remaps stored items
+type Repository interface {
+	GetPublicationUUIDs(ctx context.Context) ([]uuid.UUID, error)
+	GetItemIdentities(ctx context.Context, publicationUUID uuid.UUID) ([]*entity.Item, error)
+	RemapItemUUID(ctx context.Context, from uuid.UUID, to uuid.UUID) error
+	DeleteDuplicateItem(ctx context.Context, UUID uuid.UUID, originalUUID uuid.UUID) error
+}
+
+// Options define remapped items and how duplicates are handled
+type Options struct {
+	// Publications to remap, all publications are remapped if it is empty
+	Publications []uuid.UUID
+	// DryRun only reports changes
+	DryRun bool
+	// DeleteDuplicates deletes items, which new UUID belongs to another item.
+	// The earliest published item keeps the UUID, otherwise duplicates are reported and kept.
+	DeleteDuplicates bool
+}
+
+// Result counts remapped items
+type Result struct {
+	Checked    int
+	Remapped   int
+	Duplicates int
+	Deleted    int
+}
+
+// Remapper changes UUIDs of stored items to the ones, derived by current identity strategies
+type Remapper struct {
+	repository Repository
+	identities *entity.IdentityStrategies
+	logger     Logger
+}
+
+// New creates remapper
+func New(repository Repository, identities *entity.IdentityStrategies, logger Logger) *Remapper {
+	return &Remapper{
+		repository: repository,
+		identities: identities,
+		logger:     logger,
+	}
+}
+
+// Run remaps items of publications. It could be rerun after failure, already remapped items are not changed.
+func (r *Remapper) Run(ctx context.Context, options Options) (*Result, error) {
+	publications := options.Publications
+	if len(publications) == 0 {
+		var err error
+		if publications, err = r.repository.GetPublicationUUIDs(ctx); err != nil {
+			return nil, err
+		}
+	}
+	result := &Result{}
+	for _, publicationUUID := range publications {
+		if err := r.remapPublication(ctx, publicationUUID, options, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// remapPublication remaps items of publication, UUIDs are unique within publication namespace
+func (r *Remapper) remapPublication(ctx context.Context, publicationUUID uuid.UUID, options Options, result *Result) error {
+	strategy := r.identities.For(publicationUUID)
+	r.logger.Info("Remapping items of publication ", publicationUUID, " with ", strategy.Name(), " identity strategy")
+	items, err := r.repository.GetItemIdentities(ctx, publicationUUID)
+	if err != nil {
+		return err
+	}
+	// Taken UUIDs are tracked to report duplicates in dry run too
+	taken := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		taken[item.UUID] = true
+	}
+	for _, item := range items {
+		result.Checked++
+		newUUID := entity.NewItemUUID(item.ItemCore, strategy)
+		if newUUID == item.UUID {
+			continue
+		}
+		if taken[newUUID] {
+			result.Duplicates++
+			if err := r.handleDuplicate(ctx, item, newUUID, options, result); err != nil {
+				return err
+			}
+			continue
+		}
+		if !options.DryRun {
+			err := r.repository.RemapItemUUID(ctx, item.UUID, newUUID)
+			if errors.Is(err, entity.ErrItemNotFound) {
+				// Item was remapped or deleted since items were read
+				r.logger.Debug("Item ", item.UUID, " is already remapped or deleted")
+				continue
+			}
+			if errors.Is(err, entity.ErrItemExists) {
+				// Item was created by worker since items were read
+				result.Duplicates++
+				if err := r.handleDuplicate(ctx, item, newUUID, options, result); err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		r.logger.Debug("Remapped item ", item.UUID, " to ", newUUID)
+		delete(taken, item.UUID)
+		taken[newUUID] = true
+		result.Remapped++
+	}
+	return nil
+}
+
+func (r *Remapper) handleDuplicate(ctx context.Context, item *entity.Item, newUUID uuid.UUID, options Options, result *Result) error {
+	if !options.DeleteDuplicates {
+		r.logger.Warn("Item ", item.UUID, " is a duplicate of item ", newUUID, ", kept with old UUID")
+		return nil
+	}
+	if !options.DryRun {
+		err := r.repository.DeleteDuplicateItem(ctx, item.UUID, newUUID)
+		if errors.Is(err, entity.ErrItemNotFound) {
+			r.logger.Debug("Item ", item.UUID, " is already deleted")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	r.logger.Info("Deleted item ", item.UUID, ", duplicate of item ", newUUID)
+	result.Deleted++
+	return nil
+}
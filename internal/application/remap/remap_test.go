@@ -0,0 +1,89 @@
+package remap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(args ...interface{}) {}
+func (nopLogger) Info(args ...interface{})  {}
+func (nopLogger) Warn(args ...interface{})  {}
+func (nopLogger) Error(args ...interface{}) {}
+func (nopLogger) Fatal(args ...interface{}) {}
+
+// testRepository returns listed items, while stored items could be changed concurrently
+type testRepository struct {
+	listed []*entity.Item
+	stored map[uuid.UUID]bool
+}
+
+func (r *testRepository) GetPublicationUUIDs(ctx context.Context) ([]uuid.UUID, error) {
+	return []uuid.UUID{r.listed[0].PublicationUUID}, nil
+}
+
+func (r *testRepository) GetItemIdentities(ctx context.Context, publicationUUID uuid.UUID) ([]*entity.Item, error) {
+	return r.listed, nil
+}
+
+func (r *testRepository) RemapItemUUID(ctx context.Context, from uuid.UUID, to uuid.UUID) error {
+	if !r.stored[from] {
+		return entity.ErrItemNotFound
+	}
+	if r.stored[to] {
+		return entity.ErrItemExists
+	}
+	delete(r.stored, from)
+	r.stored[to] = true
+	return nil
+}
+
+func (r *testRepository) DeleteDuplicateItem(ctx context.Context, UUID uuid.UUID, originalUUID uuid.UUID) error {
+	if !r.stored[UUID] {
+		return entity.ErrItemNotFound
+	}
+	delete(r.stored, UUID)
+	return nil
+}
+
+func newTestItem(publicationUUID uuid.UUID, guid string, publishedDate time.Time) *entity.Item {
+	return &entity.Item{
+		UUID: uuid.Must(uuid.NewV4()),
+		ItemCore: &entity.ItemCore{
+			PublicationUUID: publicationUUID,
+			PublishedDate:   publishedDate,
+			Title:           "Budget approved",
+			GUID:            guid,
+		},
+	}
+}
+
+func TestRunSkipsConcurrentlyChangedItems(t *testing.T) {
+	publicationUUID := uuid.Must(uuid.NewV4())
+	publishedDate := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	original := newTestItem(publicationUUID, "guid-1", publishedDate)
+	duplicate := newTestItem(publicationUUID, "guid-1", publishedDate.Add(time.Hour))
+	removed := newTestItem(publicationUUID, "guid-2", publishedDate.Add(2*time.Hour))
+	repository := &testRepository{
+		listed: []*entity.Item{original, duplicate, removed},
+		// Duplicate and removed items were deleted since items were listed
+		stored: map[uuid.UUID]bool{original.UUID: true},
+	}
+	identities := &entity.IdentityStrategies{Default: entity.GUIDIdentity{}}
+
+	result, err := New(repository, identities, nopLogger{}).Run(context.Background(), Options{DeleteDuplicates: true})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Checked != 3 || result.Remapped != 1 || result.Duplicates != 1 || result.Deleted != 0 {
+		t.Errorf("Run() = %+v, want 3 checked, 1 remapped, 1 duplicate and 0 deleted", result)
+	}
+	if newUUID := entity.NewItemUUID(original.ItemCore, entity.GUIDIdentity{}); !repository.stored[newUUID] || len(repository.stored) != 1 {
+		t.Errorf("stored items = %v, want only remapped item %s", repository.stored, newUUID)
+	}
+}
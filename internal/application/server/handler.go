@@ -1,34 +1,143 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	gqlHandler "github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/internal/graph/cache"
 	"github.com/Tarick/naca-items/internal/graph/generated"
+	"github.com/Tarick/naca-items/internal/graph/hub"
+	"github.com/Tarick/naca-items/internal/graph/limits"
+	gqlMetrics "github.com/Tarick/naca-items/internal/graph/metrics"
+	"github.com/Tarick/naca-items/internal/graph/persisted"
 	"github.com/Tarick/naca-items/internal/graph/resolver"
 	gqlTracing "github.com/Tarick/naca-items/internal/graph/tracing"
+	"github.com/Tarick/naca-items/internal/processor"
 	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
+// GraphQLConfig defines GraphQL endpoint limits
+type GraphQLConfig struct {
+	// MaxDepth limits nesting of operation selection sets, 0 disables the limit
+	MaxDepth int `mapstructure:"max_depth"`
+	// MaxComplexity limits operation complexity, where list fields are multiplied by requested page size, 0 disables the limit
+	MaxComplexity int `mapstructure:"max_complexity"`
+	// PersistedQueries configures Automatic Persisted Queries and operations allowlist
+	PersistedQueries persisted.Config `mapstructure:"persisted_queries"`
+	// ResponseCache configures caching of query responses and coalescing of identical queries
+	ResponseCache cache.Config `mapstructure:"response_cache"`
+	// Metrics configures recorded operation names
+	Metrics gqlMetrics.Config `mapstructure:"metrics"`
+}
+
 // NewHandler creates http handler
-func NewHandler(logger Logger, tracer opentracing.Tracer, itemsRepository resolver.ItemsRepository) *Handler {
-	graphqlSchema := generated.NewExecutableSchema(generated.Config{Resolvers: &resolver.Resolver{ItemsRepository: itemsRepository}})
-	graphqlSrv := gqlHandler.NewDefaultServer(graphqlSchema)
+func NewHandler(config GraphQLConfig, logger Logger, tracer opentracing.Tracer, itemsRepository resolver.ItemsRepository,
+	identities *entity.IdentityStrategies, processingStages []processor.Stage) (*Handler, error) {
+	itemsHub := hub.New(itemsRepository, logger)
+	graphqlSchema := generated.NewExecutableSchema(generated.Config{
+		Resolvers:  &resolver.Resolver{ItemsRepository: itemsRepository, ItemsHub: itemsHub, Identities: identities, Stages: processingStages},
+		Complexity: resolver.NewComplexityRoot(),
+	})
+	graphqlSrv := gqlHandler.New(graphqlSchema)
+	graphqlSrv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
+	graphqlSrv.AddTransport(transport.Options{})
+	graphqlSrv.AddTransport(transport.GET{})
+	graphqlSrv.AddTransport(transport.POST{})
+	graphqlSrv.AddTransport(transport.MultipartForm{})
+	graphqlSrv.SetQueryCache(lru.New(1000))
+	graphqlSrv.Use(extension.Introspection{})
+	allowlist, err := usePersistedQueries(graphqlSrv, config.PersistedQueries, logger)
+	if err != nil {
+		return nil, err
+	}
+	if config.MaxDepth > 0 {
+		graphqlSrv.Use(limits.NewDepthLimit(config.MaxDepth))
+	}
+	if config.MaxComplexity > 0 {
+		graphqlSrv.Use(extension.FixedComplexityLimit(config.MaxComplexity))
+	}
 	graphqlSrv.Use(gqlTracing.New(tracer))
+	// Allowlisted operation names are always recorded, others up to the configured limit
+	graphqlSrv.Use(gqlMetrics.New(config.Metrics, allowlist.OperationNames()...))
+	var responseCache *cache.ResponseCache
+	if config.ResponseCache.Enabled {
+		if responseCache, err = cache.New(config.ResponseCache); err != nil {
+			return nil, err
+		}
+		graphqlSrv.Use(responseCache)
+	}
+	graphqlSrv.SetErrorPresenter(presentError)
 	return &Handler{
-		logger:     logger,
-		repository: itemsRepository,
-		gqlHandler: graphqlSrv,
-		tracer:     tracer,
+		logger:        logger,
+		repository:    itemsRepository,
+		gqlHandler:    graphqlSrv,
+		tracer:        tracer,
+		responseCache: responseCache,
+		itemsHub:      itemsHub,
+	}, nil
+}
+
+// usePersistedQueries enables allowlist in strict mode, otherwise Automatic Persisted Queries with allowlist preloaded to cache.
+// Returns loaded allowlist or nil, if it is not configured.
+func usePersistedQueries(graphqlSrv *gqlHandler.Server, config persisted.Config, logger Logger) (*persisted.Allowlist, error) {
+	var allowlist *persisted.Allowlist
+	if config.AllowlistFile != "" {
+		var err error
+		if allowlist, err = persisted.LoadAllowlist(config.AllowlistFile); err != nil {
+			return nil, err
+		}
+		logger.Info("Loaded ", allowlist.Len(), " allowlisted queries from ", config.AllowlistFile)
+	}
+	if config.Strict {
+		if allowlist == nil {
+			return nil, errors.New("strict persisted queries mode requires allowlist file")
+		}
+		graphqlSrv.Use(allowlist)
+		return allowlist, nil
 	}
+	cache, err := persisted.NewCache(config)
+	if err != nil {
+		return nil, err
+	}
+	if allowlist != nil {
+		allowlist.Preload(context.Background(), cache)
+	}
+	graphqlSrv.Use(extension.AutomaticPersistedQuery{Cache: cache})
+	return allowlist, nil
 }
 
 // Handler provides http handlers
 type Handler struct {
-	logger     Logger
-	repository resolver.ItemsRepository
-	gqlHandler *gqlHandler.Server
-	tracer     opentracing.Tracer
+	logger        Logger
+	repository    resolver.ItemsRepository
+	gqlHandler    *gqlHandler.Server
+	tracer        opentracing.Tracer
+	responseCache *cache.ResponseCache
+	itemsHub      *hub.Hub
+}
+
+// HandleItemEvent updates handler state on items changes made by any application instance and notifies subscriptions
+func (h *Handler) HandleItemEvent(event *entity.ItemEvent) {
+	if h.responseCache != nil {
+		h.responseCache.HandleItemEvent(event)
+	}
+	h.itemsHub.HandleItemEvent(event)
+}
+
+// CloseSubscriptions ends all GraphQL subscriptions, http server shutdown doesn't close websocket connections
+func (h *Handler) CloseSubscriptions() {
+	h.itemsHub.Close()
 }
 
 func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
@@ -42,3 +151,33 @@ func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("."))
 }
+
+// errorCodes maps error classes to GraphQL error extension codes
+var errorCodes = map[error]string{
+	processor.ErrMalformedMessage:      "BAD_REQUEST",
+	processor.ErrUnknownMessageType:    "BAD_REQUEST",
+	processor.ErrValidation:            "VALIDATION_FAILED",
+	processor.ErrDuplicateItem:         "ALREADY_EXISTS",
+	processor.ErrItemNotFound:          "NOT_FOUND",
+	processor.ErrItemStateUnchanged:    "STATE_UNCHANGED",
+	processor.ErrRepositoryUnavailable: "UNAVAILABLE",
+}
+
+// presentError adds error class code, retry hint and validation details to extensions of classified errors
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	class := processor.ErrorClass(err)
+	if class == nil {
+		return gqlErr
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["code"] = errorCodes[class]
+	gqlErr.Extensions["retryable"] = processor.IsRetryable(err)
+	var validationErr *processor.ValidationError
+	if errors.As(err, &validationErr) {
+		gqlErr.Extensions["fields"] = validationErr.Fields
+	}
+	return gqlErr
+}
@@ -1,9 +1,11 @@
 package server
 
 import (
+	"context"
 	"net/http"
 	"time"
 
+	"github.com/Tarick/naca-items/internal/application/health"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	// "github.com/99designs/gqlgen/graphql/playground"
@@ -21,13 +23,14 @@ type Server struct {
 
 // Config defines webserver configuration
 type Config struct {
-	Address        string `mapstructure:"address"`
-	RequestTimeout int    `mapstructure:"request_timeout"`
+	Address        string        `mapstructure:"address"`
+	RequestTimeout int           `mapstructure:"request_timeout"`
+	GraphQL        GraphQLConfig `mapstructure:"graphql"`
 }
 
 // New creates new server configuration and configurates middleware
 // TODO: move routes to handler file
-func New(serverConfig Config, logger Logger, handler *Handler) *Server {
+func New(serverConfig Config, logger Logger, handler *Handler, health *health.Health) *Server {
 	r := chi.NewRouter()
 	s := &Server{
 		httpServer: &http.Server{Addr: serverConfig.Address, Handler: r},
@@ -41,17 +44,16 @@ func New(serverConfig Config, logger Logger, handler *Handler) *Server {
 		// Prometheus metrics
 		r.Handle("/metrics", promhttp.Handler())
 		r.Get("/healthz", http.HandlerFunc(handler.healthCheck))
+		r.Get("/livez", health.Livez)
+		r.Get("/readyz", health.Readyz)
 	})
 	r.Group(func(r chi.Router) {
 		// r.Handle("/", playground.Handler("GraphQL playground", "/query"))
 		r.Use(middleware.RequestID)
 		r.Use(middlewareLogger(logger))
 		r.Route("/query", func(r chi.Router) {
-			// Set 1 second caching and requests coalescing to avoid requests stampede. Beware of any user specific responses.
-			// cached := stampede.Handler(512, 1*time.Second)
-			// r.With(cached).Get("/", gqlHandler)
+			// Responses caching and requests coalescing is done by GraphQL handler, see GraphQLConfig.ResponseCache
 			r.Handle("/", handler.gqlHandler)
-
 		})
 
 	})
@@ -66,3 +68,9 @@ func (s *Server) StartAndServe() error {
 	}
 	return nil
 }
+
+// Shutdown stops accepting new connections and waits for in-flight requests to finish until context is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("Server is shutting down")
+	return s.httpServer.Shutdown(ctx)
+}
@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DefaultRemovedURLParams are tracking and session parameters, removed from canonical URL by default
+var DefaultRemovedURLParams = []string{
+	"utm_*", "fbclid", "gclid", "dclid", "msclkid", "yclid", "igshid", "mc_cid", "mc_eid", "_ga", "_hsenc", "_hsmi",
+	"jsessionid", "phpsessid", "aspsessionid*", "sessionid", "session_id",
+}
+
+// URLCanonicalizer brings URLs of the same article to one canonical URL by configurable rules,
+// so it doesn't depend on tracking parameters, session IDs, scheme or host case.
+// Scheme is always lower-cased.
+type URLCanonicalizer struct {
+	// HTTPS replaces http scheme with https
+	HTTPS bool
+	// LowercaseHost lower-cases host name
+	LowercaseHost bool
+	// RemoveWWW removes "www." prefix of host name
+	RemoveWWW bool
+	// RemoveDefaultPort removes default port of URL scheme, 80 of http and 443 of https
+	RemoveDefaultPort bool
+	// RemoveFragment removes #fragment
+	RemoveFragment bool
+	// SortQuery orders query parameters by name
+	SortQuery bool
+	// RemoveParams are case-insensitive names of query and path (e.g. ;jsessionid=) parameters to remove.
+	// Name ending with * removes all parameters with the prefix, e.g. utm_*
+	RemoveParams []string
+}
+
+// NewURLCanonicalizer creates canonicalizer with default rules: https scheme, lower case host without default port,
+// no fragment, sorted query without DefaultRemovedURLParams
+func NewURLCanonicalizer() *URLCanonicalizer {
+	return &URLCanonicalizer{
+		HTTPS:             true,
+		LowercaseHost:     true,
+		RemoveDefaultPort: true,
+		RemoveFragment:    true,
+		SortQuery:         true,
+		RemoveParams:      DefaultRemovedURLParams,
+	}
+}
+
+// Canonicalize returns canonical URL of absolute URL. Empty URL is returned as is.
+func (c *URLCanonicalizer) Canonicalize(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("url %q is not absolute", rawURL)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	// Default port depends on the original scheme, e.g. https://example.com:80 is not https://example.com
+	u.Host = c.canonicalHost(u)
+	if c.HTTPS && u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	if path := c.removePathParams(u.Path); path != u.Path {
+		u.Path, u.RawPath = path, ""
+	}
+	if c.RemoveFragment {
+		u.Fragment, u.RawFragment = "", ""
+	}
+	u.RawQuery = c.canonicalQuery(u.RawQuery)
+	u.ForceQuery = false
+	return u.String(), nil
+}
+
+// CanonicalizeURL returns canonical URL with default rules, see NewURLCanonicalizer
+func CanonicalizeURL(rawURL string) (string, error) {
+	return NewURLCanonicalizer().Canonicalize(rawURL)
+}
+
+// defaultPorts maps schemes to their default ports
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+func (c *URLCanonicalizer) canonicalHost(u *url.URL) string {
+	host, port := u.Hostname(), u.Port()
+	if c.LowercaseHost {
+		host = strings.ToLower(host)
+	}
+	if c.RemoveWWW && len(host) > 4 && strings.EqualFold(host[:4], "www.") {
+		host = host[4:]
+	}
+	if c.RemoveDefaultPort && port == defaultPorts[u.Scheme] {
+		port = ""
+	}
+	if port != "" {
+		return net.JoinHostPort(host, port)
+	}
+	// IPv6 address must stay in brackets
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+// removePathParams removes matching parameters of path segments, e.g. /article;jsessionid=1
+func (c *URLCanonicalizer) removePathParams(path string) string {
+	if !strings.Contains(path, ";") {
+		return path
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		params := strings.Split(segment, ";")
+		kept := params[:1]
+		for _, param := range params[1:] {
+			if !c.isRemovedParam(strings.SplitN(param, "=", 2)[0]) {
+				kept = append(kept, param)
+			}
+		}
+		segments[i] = strings.Join(kept, ";")
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery removes matching parameters and sorts the rest, if required.
+// Parameters are not decoded, so their encoding and the order of repeated parameters are kept.
+func (c *URLCanonicalizer) canonicalQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	params := []string{}
+	for _, param := range strings.Split(rawQuery, "&") {
+		name := strings.SplitN(param, "=", 2)[0]
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		if name != "" && !c.isRemovedParam(name) {
+			params = append(params, param)
+		}
+	}
+	if c.SortQuery {
+		sort.SliceStable(params, func(i, j int) bool {
+			return strings.SplitN(params[i], "=", 2)[0] < strings.SplitN(params[j], "=", 2)[0]
+		})
+	}
+	return strings.Join(params, "&")
+}
+
+func (c *URLCanonicalizer) isRemovedParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, removed := range c.RemoveParams {
+		removed = strings.ToLower(removed)
+		if strings.HasSuffix(removed, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(removed, "*")) {
+				return true
+			}
+		} else if name == removed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package entity
+
+import "testing"
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "empty", url: "  ", want: ""},
+		{name: "canonical url kept", url: "https://example.com/news/1", want: "https://example.com/news/1"},
+		{name: "https scheme", url: "http://example.com/news/1", want: "https://example.com/news/1"},
+		{name: "scheme and host lower-cased", url: "HTTPS://Example.COM/News/1", want: "https://example.com/News/1"},
+		{name: "default https port removed", url: "https://example.com:443/news/1", want: "https://example.com/news/1"},
+		{name: "default http port removed", url: "http://example.com:80/news/1", want: "https://example.com/news/1"},
+		{name: "http port of https kept", url: "https://example.com:80/news/1", want: "https://example.com:80/news/1"},
+		{name: "https port of http kept", url: "http://example.com:443/news/1", want: "https://example.com:443/news/1"},
+		{name: "other port kept", url: "https://example.com:8443/news/1", want: "https://example.com:8443/news/1"},
+		{name: "www kept by default", url: "https://www.example.com/news/1", want: "https://www.example.com/news/1"},
+		{name: "fragment removed", url: "https://example.com/news/1#comments", want: "https://example.com/news/1"},
+		{name: "utm parameters removed", url: "https://example.com/news/1?utm_source=feed&utm_medium=rss&UTM_Campaign=x", want: "https://example.com/news/1"},
+		{name: "click identifiers removed", url: "https://example.com/news/1?fbclid=abc&gclid=def&id=5", want: "https://example.com/news/1?id=5"},
+		{name: "session path parameter removed", url: "https://example.com/news/1;jsessionid=ABC", want: "https://example.com/news/1"},
+		{name: "query sorted", url: "https://example.com/news?page=2&id=5&a=1", want: "https://example.com/news?a=1&id=5&page=2"},
+		{name: "repeated parameters order kept", url: "https://example.com/news?tag=b&id=5&tag=a", want: "https://example.com/news?id=5&tag=b&tag=a"},
+		{name: "parameter encoding kept", url: "https://example.com/search?q=caf%C3%A9+news", want: "https://example.com/search?q=caf%C3%A9+news"},
+		{name: "empty query removed", url: "https://example.com/news/1?", want: "https://example.com/news/1"},
+		{name: "ipv6 host", url: "http://[::1]:80/news", want: "https://[::1]/news"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalizeURL(tt.url)
+			if err != nil {
+				t.Fatalf("CanonicalizeURL(%q) error = %v", tt.url, err)
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalizeURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeURLInvalid(t *testing.T) {
+	for _, url := range []string{"/news/1", "example.com/news/1", "http://exa mple.com/%zz"} {
+		if got, err := CanonicalizeURL(url); err == nil {
+			t.Errorf("CanonicalizeURL(%q) = %q, want error", url, got)
+		}
+	}
+}
+
+func TestURLCanonicalizerRules(t *testing.T) {
+	const url = "http://WWW.Example.com:80/news/1?utm_source=feed&b=2&a=1&ref=home#top"
+	tests := []struct {
+		name          string
+		canonicalizer *URLCanonicalizer
+		want          string
+	}{
+		{
+			name:          "no rules",
+			canonicalizer: &URLCanonicalizer{},
+			want:          "http://WWW.Example.com:80/news/1?utm_source=feed&b=2&a=1&ref=home#top",
+		},
+		{
+			name:          "remove www",
+			canonicalizer: &URLCanonicalizer{LowercaseHost: true, RemoveWWW: true},
+			want:          "http://example.com:80/news/1?utm_source=feed&b=2&a=1&ref=home#top",
+		},
+		{
+			name:          "sort query only",
+			canonicalizer: &URLCanonicalizer{SortQuery: true},
+			want:          "http://WWW.Example.com:80/news/1?a=1&b=2&ref=home&utm_source=feed#top",
+		},
+		{
+			name:          "custom removed parameters replace defaults",
+			canonicalizer: &URLCanonicalizer{RemoveParams: []string{"ref"}},
+			want:          "http://WWW.Example.com:80/news/1?utm_source=feed&b=2&a=1#top",
+		},
+		{
+			name:          "default rules",
+			canonicalizer: NewURLCanonicalizer(),
+			want:          "https://www.example.com/news/1?a=1&b=2&ref=home",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.canonicalizer.Canonicalize(url)
+			if err != nil {
+				t.Fatalf("Canonicalize() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Canonicalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ItemCursor defines position of item in the list of items sorted by published date and UUID.
+// It is used as a key for keyset pagination, UUID disambiguates items with the same published date.
+type ItemCursor struct {
+	PublishedDate time.Time
+	UUID          uuid.UUID
+}
+
+// NewItemCursor returns cursor pointing to item
+func NewItemCursor(item *Item) *ItemCursor {
+	return &ItemCursor{PublishedDate: item.PublishedDate, UUID: item.UUID}
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "github.com/gofrs/uuid"
+
+// ItemEventOperation is the change of item, which caused the event
+type ItemEventOperation string
+
+const (
+	ItemEventInsert ItemEventOperation = "insert"
+	ItemEventUpdate ItemEventOperation = "update"
+	ItemEventDelete ItemEventOperation = "delete"
+)
+
+// ItemEvent notifies about item change in repository
+type ItemEvent struct {
+	Operation       ItemEventOperation `json:"op"`
+	UUID            uuid.UUID          `json:"uuid"`
+	PublicationUUID uuid.UUID          `json:"publication_uuid"`
+	LanguageCode    string             `json:"language_code"`
+}
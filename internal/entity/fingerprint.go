@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// NearDuplicateDistance is the maximum number of different bits in fingerprints of near-duplicate items.
+	// Repository splits fingerprint into NearDuplicateDistance+1 8-bit bands to find near-duplicates, which share at least one band.
+	NearDuplicateDistance = 7
+	// NearDuplicateWindow limits near-duplicates to items published around the same time
+	NearDuplicateWindow = 72 * time.Hour
+)
+
+// NewFingerprint returns SimHash of text words, similar texts have fingerprints with few different bits.
+// News items are short, so words are used as features instead of shingles: edited title or added byline
+// changes few bits, while unrelated stories differ in about a half of them.
+// Letter case, punctuation and whitespace are ignored. Returns 0 for text without words.
+func NewFingerprint(text string) uint64 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return 0
+	}
+	var weights [64]int
+	for _, word := range words {
+		hash := fnv.New64a()
+		hash.Write([]byte(word))
+		sum := hash.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << uint(bit)
+		}
+	}
+	return fingerprint
+}
+
+// FingerprintDistance returns number of different bits in fingerprints
+func FingerprintDistance(a uint64, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// IsNearDuplicate reports if fingerprints belong to near-duplicate texts
+func IsNearDuplicate(a uint64, b uint64) bool {
+	return a != 0 && b != 0 && FingerprintDistance(a, b) <= NearDuplicateDistance
+}
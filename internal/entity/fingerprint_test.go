@@ -0,0 +1,78 @@
+package entity
+
+import "testing"
+
+const storyText = `The city council approved the new budget on Tuesday evening after a long debate about public transport,
+schools and the maintenance of roads and bridges across the region. The mayor said the plan would add two hundred buses
+over the next three years and repair the old river bridge, which has been closed to heavy traffic since the spring floods.
+Opposition members criticised the rise in parking fees and asked for more spending on libraries and youth centres.`
+
+func TestNewFingerprintIgnoresCaseAndPunctuation(t *testing.T) {
+	a := NewFingerprint("Council approves budget: buses, bridges and schools")
+	b := NewFingerprint("  council APPROVES budget -- buses bridges, and schools!  ")
+	if a != b {
+		t.Errorf("fingerprints differ by %d bits, want equal", FingerprintDistance(a, b))
+	}
+}
+
+func TestNewFingerprintWithoutWords(t *testing.T) {
+	for _, text := range []string{"", "   ", "... -- !!!"} {
+		if got := NewFingerprint(text); got != 0 {
+			t.Errorf("NewFingerprint(%q) = %x, want 0", text, got)
+		}
+	}
+}
+
+func TestNearDuplicates(t *testing.T) {
+	original := NewFingerprint(storyText)
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{name: "same text", text: storyText, want: true},
+		{name: "added byline", text: "By Jane Smith, City Reporter. " + storyText, want: true},
+		{name: "edited word", text: "The city council passed" + storyText[len("The city council approved"):], want: true},
+		{
+			name: "unrelated story",
+			text: `The national football team won the championship final on Sunday night after a dramatic penalty shootout.
+Thousands of supporters celebrated in the streets of the capital until the early morning, and the coach thanked the fans
+for their patience during a difficult season marked by injuries to several key players and a change of the team captain.`,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fingerprint := NewFingerprint(tt.text)
+			if got := IsNearDuplicate(original, fingerprint); got != tt.want {
+				t.Errorf("IsNearDuplicate() = %v with distance %d, want %v", got, FingerprintDistance(original, fingerprint), tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNearDuplicateWithoutFingerprint(t *testing.T) {
+	if IsNearDuplicate(0, 0) {
+		t.Error("items without fingerprints are near-duplicates")
+	}
+	if IsNearDuplicate(NewFingerprint(storyText), 0) {
+		t.Error("item without fingerprint is near-duplicate")
+	}
+}
+
+func TestFingerprintDistance(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int
+	}{
+		{a: 0, b: 0, want: 0},
+		{a: 0xff, b: 0, want: 8},
+		{a: 0xf0f0, b: 0x0ff0, want: 8},
+		{a: ^uint64(0), b: 0, want: 64},
+	}
+	for _, tt := range tests {
+		if got := FingerprintDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("FingerprintDistance(%x, %x) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
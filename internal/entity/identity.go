@@ -0,0 +1,149 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// IdentityStrategy defines fields, which identify item of publication.
+// Item UUID is v5 UUID with PublicationUUID as a namespace and strategy key as a name.
+type IdentityStrategy interface {
+	// Name is used in configuration
+	Name() string
+	// Key returns identity key of item or false, if item doesn't have fields, required by strategy
+	Key(core *ItemCore) (string, bool)
+}
+
+// Identity strategies names
+const (
+	IdentityTitleDate    = "title_date"
+	IdentityGUID         = "guid"
+	IdentityURL          = "url"
+	IdentityCanonicalURL = "canonical_url"
+)
+
+// TitleDateIdentity identifies item by Title and PublishedDate. It is the default strategy, which always has the key.
+// Edited headline or changed date at the source make another item.
+type TitleDateIdentity struct{}
+
+// Name returns strategy name
+func (TitleDateIdentity) Name() string {
+	return IdentityTitleDate
+}
+
+// Key returns Title and PublishedDate
+func (TitleDateIdentity) Key(core *ItemCore) (string, bool) {
+	return fmt.Sprint(core.Title, "_", core.PublishedDate), true
+}
+
+// GUIDIdentity identifies item by feed entry GUID, falls back to URL if item has no GUID
+type GUIDIdentity struct{}
+
+// Name returns strategy name
+func (GUIDIdentity) Name() string {
+	return IdentityGUID
+}
+
+// Key returns GUID or URL
+func (GUIDIdentity) Key(core *ItemCore) (string, bool) {
+	if guid := strings.TrimSpace(core.GUID); guid != "" {
+		return "guid:" + guid, true
+	}
+	return URLIdentity{}.Key(core)
+}
+
+// URLIdentity identifies item by URL as it is received
+type URLIdentity struct{}
+
+// Name returns strategy name
+func (URLIdentity) Name() string {
+	return IdentityURL
+}
+
+// Key returns URL
+func (URLIdentity) Key(core *ItemCore) (string, bool) {
+	if u := strings.TrimSpace(core.URL); u != "" {
+		return "url:" + u, true
+	}
+	return "", false
+}
+
+// CanonicalURLIdentity identifies item by canonical URL, so the same article with different tracking parameters,
+// scheme or host case is the same item. Canonical URL key is the same as URL one for already canonical URL.
+type CanonicalURLIdentity struct {
+	// Canonicalizer defines canonicalization rules, default rules are used if it is nil
+	Canonicalizer *URLCanonicalizer
+}
+
+// Name returns strategy name
+func (CanonicalURLIdentity) Name() string {
+	return IdentityCanonicalURL
+}
+
+// Key returns canonical URL
+func (i CanonicalURLIdentity) Key(core *ItemCore) (string, bool) {
+	canonicalizer := i.Canonicalizer
+	if canonicalizer == nil {
+		canonicalizer = NewURLCanonicalizer()
+	}
+	canonical, err := canonicalizer.Canonicalize(core.URL)
+	if err != nil || canonical == "" {
+		return "", false
+	}
+	return "url:" + canonical, true
+}
+
+// NewIdentityStrategy returns identity strategy by name, canonicalizer is used by canonical_url strategy
+func NewIdentityStrategy(name string, canonicalizer *URLCanonicalizer) (IdentityStrategy, error) {
+	switch name {
+	case IdentityTitleDate, "":
+		return TitleDateIdentity{}, nil
+	case IdentityGUID:
+		return GUIDIdentity{}, nil
+	case IdentityURL:
+		return URLIdentity{}, nil
+	case IdentityCanonicalURL:
+		return CanonicalURLIdentity{Canonicalizer: canonicalizer}, nil
+	default:
+		return nil, fmt.Errorf("unknown identity strategy %q", name)
+	}
+}
+
+// NewItemUUID returns UUID of item, identified by strategy. Title and PublishedDate are used, if item doesn't have strategy fields.
+func NewItemUUID(core *ItemCore, strategy IdentityStrategy) uuid.UUID {
+	key, ok := strategy.Key(core)
+	if !ok {
+		key, _ = TitleDateIdentity{}.Key(core)
+	}
+	return uuid.NewV5(core.PublicationUUID, key)
+}
+
+// IdentityStrategies selects identity strategy of publication, the default one is used for not configured publications
+type IdentityStrategies struct {
+	Default      IdentityStrategy
+	Publications map[uuid.UUID]IdentityStrategy
+}
+
+// For returns identity strategy of publication
+func (s *IdentityStrategies) For(publicationUUID uuid.UUID) IdentityStrategy {
+	if s == nil {
+		return TitleDateIdentity{}
+	}
+	if strategy, ok := s.Publications[publicationUUID]; ok {
+		return strategy
+	}
+	if s.Default == nil {
+		return TitleDateIdentity{}
+	}
+	return s.Default
+}
+
+// NewItem creates item with UUID, derived by publication identity strategy
+func (s *IdentityStrategies) NewItem(core *ItemCore) *Item {
+	item := &Item{}
+	item.ItemCore = core
+	item.UUID = NewItemUUID(core, s.For(core.PublicationUUID))
+	return item
+}
@@ -11,10 +11,30 @@ import (
 	"github.com/gofrs/uuid"
 )
 
+// ErrItemExists is returned by repository on attempt to store item with already stored UUID or canonical URL of publication
+var ErrItemExists = errors.New("item already exists")
+
+// ErrItemNotFound is returned by repository on attempt to change item, which isn't stored
+var ErrItemNotFound = errors.New("item not found")
+
 // Item defines news item type
 type Item struct {
 	UUID uuid.UUID `json:"uuid"`
 	*ItemCore
+	// ContentText is plain text version of Content, produced by item processing
+	ContentText string `json:"content_text,omitempty"`
+	// DeclaredLanguageCode is the language code item was received with, LanguageCode could be corrected by language detection
+	DeclaredLanguageCode string `json:"declared_language_code,omitempty"`
+	// DetectedLanguageCode is the language code found by language detection, empty if detection wasn't confident
+	DetectedLanguageCode string `json:"detected_language_code,omitempty"`
+	// Fingerprint is SimHash of item text to find near-duplicates, see NewFingerprint. Zero if not computed
+	Fingerprint uint64 `json:"fingerprint,omitempty"`
+	// CanonicalURL is URL without tracking parameters, produced by item processing, see URLCanonicalizer.
+	// Items of publication are unique by canonical URL. Empty if URL couldn't be canonicalized
+	CanonicalURL string `json:"canonical_url,omitempty"`
+	// ClusterID links near-duplicate items of the same story, e.g. syndicated by several publications.
+	// Zero if item has no known near-duplicates
+	ClusterID int64 `json:"cluster_id,omitempty"`
 }
 
 // Validate checks validity of item fields
@@ -38,10 +58,21 @@ type ItemCore struct {
 	Content         string    `json:"content,omitempty"`
 	URL             string    `json:"url,omitempty"`
 	LanguageCode    string    `json:"language_code"`
+	// GUID is the identifier of feed entry, used by guid identity strategy
+	GUID string `json:"guid,omitempty"`
 }
 
 // Validate checks core item fields
 func (core *ItemCore) Validate() error {
+	return core.validate(validation.Required)
+}
+
+// ValidateSubmitted checks core item fields before processing, missing language code is filled by language detection
+func (core *ItemCore) ValidateSubmitted() error {
+	return core.validate()
+}
+
+func (core *ItemCore) validate(languageRules ...validation.Rule) error {
 	return validation.ValidateStruct(core,
 		validation.Field(&core.PublicationUUID, validation.Required, is.UUID, validation.By(checkUUIDNotNil)),
 		validation.Field(&core.PublishedDate, validation.Required),
@@ -49,7 +80,7 @@ func (core *ItemCore) Validate() error {
 		validation.Field(&core.Description, validation.Length(10, 0)),
 		validation.Field(&core.Content, validation.Length(10, 0)),
 		validation.Field(&core.URL, is.URL),
-		validation.Field(&core.LanguageCode, validation.Required, validation.Length(2, 2), isLanguageCode),
+		validation.Field(&core.LanguageCode, append(languageRules, validation.Length(2, 2), isLanguageCode)...),
 	)
 }
 
@@ -72,11 +103,11 @@ var isLanguageCode = validation.NewStringRuleWithError(
 	validation.NewError("validation_is_language_code_2_letter", "must be a valid two-letter ISO693Alpha2 language code"))
 
 // NewFilledItem creates new item with set UUID v5, using PublicationUUID as a namespace and Title and PublishedDate as a key
-// This ensures uniquness of published item
+// This ensures uniquness of published item. See IdentityStrategies to identify items of publication by other fields.
 func NewFilledItem(core *ItemCore) *Item {
 	item := &Item{}
 	item.ItemCore = core
-	item.UUID = uuid.NewV5(item.PublicationUUID, fmt.Sprint(item.Title, "_", item.PublishedDate))
+	item.UUID = NewItemUUID(core, TitleDateIdentity{})
 	return item
 }
 
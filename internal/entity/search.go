@@ -0,0 +1,35 @@
+package entity
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
+
+// ItemSearchQuery defines full-text search request.
+// Empty LanguageCode and nil PublicationUUID don't restrict search results.
+type ItemSearchQuery struct {
+	Query           string
+	LanguageCode    string
+	PublicationUUID uuid.UUID
+	Offset          int
+	Limit           int
+}
+
+// Validate checks search query fields
+func (q *ItemSearchQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.Query, validation.Required, validation.Length(1, 1000)),
+		validation.Field(&q.LanguageCode, validation.Length(2, 2), isLanguageCode),
+		validation.Field(&q.Offset, validation.Min(0)),
+		validation.Field(&q.Limit, validation.Required, validation.Min(1)),
+	)
+}
+
+// ItemSearchResult is the item found with full-text search, its rank and fields snippets with highlighted matches
+type ItemSearchResult struct {
+	Item                 *Item
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+	ContentHighlight     string
+}
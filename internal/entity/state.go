@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gofrs/uuid"
+)
+
+// ItemState defines state of item, matches types in item_state table
+type ItemState string
+
+const (
+	// ItemStateDisabled is the state of item, hidden from readers
+	ItemStateDisabled ItemState = "disabled"
+	// ItemStateValid is the state of normal visible item
+	ItemStateValid ItemState = "valid"
+)
+
+// ItemStateTransition records change of item state with the reason and the actor who made it
+type ItemStateTransition struct {
+	ItemUUID  uuid.UUID `json:"item_uuid"`
+	FromState ItemState `json:"from_state"`
+	ToState   ItemState `json:"to_state"`
+	Reason    string    `json:"reason"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Changed reports if transition changed the state of item
+func (t *ItemStateTransition) Changed() bool {
+	return t.FromState != t.ToState
+}
+
+// Validate checks fields required to request state transition
+func (t *ItemStateTransition) Validate() error {
+	return validation.ValidateStruct(t,
+		validation.Field(&t.ItemUUID, validation.Required, is.UUID, validation.By(checkUUIDNotNil)),
+		validation.Field(&t.ToState, validation.Required, validation.In(ItemStateDisabled, ItemStateValid)),
+		validation.Field(&t.Reason, validation.Required, validation.Length(3, 1000)),
+		validation.Field(&t.Actor, validation.Required, validation.Length(1, 200)),
+	)
+}
+
+// NewItemStateTransition creates transition request of item to the state
+func NewItemStateTransition(itemUUID uuid.UUID, state ItemState, reason string, actor string) *ItemStateTransition {
+	return &ItemStateTransition{
+		ItemUUID: itemUUID,
+		ToState:  state,
+		Reason:   reason,
+		Actor:    actor,
+	}
+}
@@ -0,0 +1,398 @@
+// Package cache provides gqlgen handler extension, which caches responses of queries and coalesces identical in-flight queries
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// defaultSize is used when cache size is not configured
+const defaultSize = 1000
+
+// Config defines response cache configuration
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Size is the max number of cached responses
+	Size int `mapstructure:"size"`
+	// DefaultTTL in seconds is used for operations without fields matching rules
+	DefaultTTL int `mapstructure:"default_ttl"`
+	// Rules set TTL of fields, operation is cached for the min TTL of its fields
+	Rules []Rule `mapstructure:"rules"`
+}
+
+// Rule sets TTL in seconds for field, defined as "Object.field", or for fields of type. TTL 0 disables caching.
+type Rule struct {
+	Field string `mapstructure:"field"`
+	Type  string `mapstructure:"type"`
+	TTL   int    `mapstructure:"ttl"`
+}
+
+var responses = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "naca_items",
+	Subsystem: "graphql",
+	Name:      "response_cache_total",
+	Help:      "Number of cacheable query responses by result: hit, miss or coalesced.",
+}, []string{"result"})
+
+// crossPublicationFields are "Object.field" fields, which return items of any publications
+var crossPublicationFields = map[string]bool{
+	"Item.cluster":      true,
+	"Item.relatedItems": true,
+}
+
+// scope defines items changes, which could change query response
+type scope struct {
+	// all is set if response could depend on any item
+	all          bool
+	items        map[string]bool
+	publications map[string]bool
+}
+
+// affectedBy reports if item event could change response
+func (s *scope) affectedBy(event *entity.ItemEvent) bool {
+	return s.all || s.items[event.UUID.String()] || s.publications[event.PublicationUUID.String()]
+}
+
+type entry struct {
+	response  *graphql.Response
+	expiresAt time.Time
+	scope     *scope
+}
+
+// call is in-flight query execution, which result is shared with identical queries
+type call struct {
+	wg       sync.WaitGroup
+	response *graphql.Response
+	scope    *scope
+	// stale is set if cache is invalidated during execution, so response is not cached
+	stale bool
+}
+
+// ResponseCache caches successful query responses by normalized query and variables.
+// Mutations invalidate the whole cache. Item events invalidate responses of queries by item UUID or publication UUID
+// of changed item and responses of queries, which may combine items of any publications, e.g. search without publication,
+// so under steady items ingestion such queries are rarely served from cache.
+type ResponseCache struct {
+	responses  *lru.Cache
+	defaultTTL time.Duration
+	fieldTTL   map[string]time.Duration
+	typeTTL    map[string]time.Duration
+
+	mu       sync.Mutex
+	inFlight map[string]*call
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = &ResponseCache{}
+
+// New creates response cache
+func New(config Config) (*ResponseCache, error) {
+	size := config.Size
+	if size <= 0 {
+		size = defaultSize
+	}
+	responses, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	c := &ResponseCache{
+		responses:  responses,
+		defaultTTL: time.Duration(config.DefaultTTL) * time.Second,
+		fieldTTL:   map[string]time.Duration{},
+		typeTTL:    map[string]time.Duration{},
+		inFlight:   map[string]*call{},
+	}
+	for _, rule := range config.Rules {
+		ttl := time.Duration(rule.TTL) * time.Second
+		switch {
+		case rule.Field != "" && rule.Type == "":
+			c.fieldTTL[rule.Field] = ttl
+		case rule.Type != "" && rule.Field == "":
+			c.typeTTL[rule.Type] = ttl
+		default:
+			return nil, fmt.Errorf("response cache rule must define either field or type")
+		}
+	}
+	return c, nil
+}
+
+func (c *ResponseCache) ExtensionName() string {
+	return "ResponseCache"
+}
+
+func (c *ResponseCache) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// Invalidate drops all cached responses. Responses of queries, which are in flight, won't be cached.
+func (c *ResponseCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, inFlight := range c.inFlight {
+		inFlight.stale = true
+	}
+	c.responses.Purge()
+}
+
+// HandleItemEvent drops cached responses, which could be changed by item change. Missed events invalidate the whole cache.
+func (c *ResponseCache) HandleItemEvent(event *entity.ItemEvent) {
+	if event == nil {
+		c.Invalidate()
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, inFlight := range c.inFlight {
+		if inFlight.scope.affectedBy(event) {
+			inFlight.stale = true
+		}
+	}
+	for _, key := range c.responses.Keys() {
+		if value, ok := c.responses.Peek(key); ok && value.(*entry).scope.affectedBy(event) {
+			c.responses.Remove(key)
+		}
+	}
+}
+
+// InterceptOperation serves queries from cache or coalesces them with identical in-flight query, mutations invalidate cache
+func (c *ResponseCache) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	switch oc.Operation.Operation {
+	case ast.Mutation:
+		return c.invalidateAfter(next(ctx))
+	case ast.Query:
+	default:
+		return next(ctx)
+	}
+	ttl := c.operationTTL(oc.Operation.SelectionSet)
+	if ttl <= 0 {
+		return next(ctx)
+	}
+	key, err := cacheKey(oc)
+	if err != nil {
+		return next(ctx)
+	}
+	if value, ok := c.responses.Get(key); ok {
+		e := value.(*entry)
+		if time.Now().Before(e.expiresAt) {
+			responses.WithLabelValues("hit").Inc()
+			return graphql.OneShot(copyResponse(e.response))
+		}
+		c.responses.Remove(key)
+	}
+
+	c.mu.Lock()
+	if inFlight, ok := c.inFlight[key]; ok {
+		c.mu.Unlock()
+		responses.WithLabelValues("coalesced").Inc()
+		inFlight.wg.Wait()
+		// Leader has failed without response, e.g. panicked, so query is executed on its own
+		if inFlight.response == nil {
+			return next(ctx)
+		}
+		return graphql.OneShot(copyResponse(inFlight.response))
+	}
+	inFlight := &call{scope: operationScope(oc)}
+	inFlight.wg.Add(1)
+	c.inFlight[key] = inFlight
+	c.mu.Unlock()
+	responses.WithLabelValues("miss").Inc()
+	// Waiters are released even if execution panics
+	defer func() {
+		c.mu.Lock()
+		delete(c.inFlight, key)
+		c.mu.Unlock()
+		inFlight.wg.Done()
+	}()
+
+	responseHandler := next(ctx)
+	response := responseHandler(ctx)
+	if response == nil {
+		return graphql.OneShot(response)
+	}
+	// Shared response is kept intact, the leader may modify its own copy further
+	inFlight.response = copyResponse(response)
+	c.mu.Lock()
+	if len(response.Errors) == 0 && !inFlight.stale {
+		c.responses.Add(key, &entry{response: inFlight.response, expiresAt: time.Now().Add(ttl), scope: inFlight.scope})
+	}
+	c.mu.Unlock()
+	return graphql.OneShot(response)
+}
+
+// copyResponse returns copy of response, which errors and extensions could be modified by other extensions
+// without affecting shared response. Data is never modified after execution, so it is shared.
+func copyResponse(response *graphql.Response) *graphql.Response {
+	c := *response
+	if response.Errors != nil {
+		c.Errors = make(gqlerror.List, len(response.Errors))
+		for i, err := range response.Errors {
+			e := *err
+			c.Errors[i] = &e
+		}
+	}
+	if response.Extensions != nil {
+		c.Extensions = make(map[string]interface{}, len(response.Extensions))
+		for k, v := range response.Extensions {
+			c.Extensions[k] = v
+		}
+	}
+	return &c
+}
+
+// invalidateAfter drops cached responses once mutation is executed
+func (c *ResponseCache) invalidateAfter(responseHandler graphql.ResponseHandler) graphql.ResponseHandler {
+	return func(ctx context.Context) *graphql.Response {
+		response := responseHandler(ctx)
+		if response != nil {
+			c.Invalidate()
+		}
+		return response
+	}
+}
+
+// operationTTL returns the min TTL of fields in selection set matching rules, or default TTL
+func (c *ResponseCache) operationTTL(selectionSet ast.SelectionSet) time.Duration {
+	ttl, matched := c.selectionSetTTL(selectionSet)
+	if !matched {
+		return c.defaultTTL
+	}
+	return ttl
+}
+
+func (c *ResponseCache) selectionSetTTL(selectionSet ast.SelectionSet) (time.Duration, bool) {
+	var (
+		min     time.Duration
+		matched bool
+	)
+	merge := func(ttl time.Duration) {
+		if !matched || ttl < min {
+			min, matched = ttl, true
+		}
+	}
+	for _, selection := range selectionSet {
+		var children ast.SelectionSet
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.ObjectDefinition != nil {
+				if ttl, ok := c.fieldTTL[s.ObjectDefinition.Name+"."+s.Name]; ok {
+					merge(ttl)
+				}
+			}
+			if s.Definition != nil {
+				if ttl, ok := c.typeTTL[s.Definition.Type.Name()]; ok {
+					merge(ttl)
+				}
+			}
+			children = s.SelectionSet
+		case *ast.InlineFragment:
+			children = s.SelectionSet
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				children = s.Definition.SelectionSet
+			}
+		}
+		if ttl, ok := c.selectionSetTTL(children); ok {
+			merge(ttl)
+		}
+	}
+	return min, matched
+}
+
+// operationScope returns items, which could change response of query. Top level field is limited to the item
+// by "uuid" argument or to publication by "publicationUUID" argument, unless it selects items of any publications.
+func operationScope(oc *graphql.OperationContext) *scope {
+	s := &scope{items: map[string]bool{}, publications: map[string]bool{}}
+	for _, selection := range oc.Operation.SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok {
+			return &scope{all: true}
+		}
+		// Introspection doesn't depend on items
+		if strings.HasPrefix(field.Name, "__") {
+			continue
+		}
+		if crossesPublications(field.SelectionSet) {
+			return &scope{all: true}
+		}
+		arguments := field.ArgumentMap(oc.Variables)
+		if publicationUUID, ok := arguments["publicationUUID"].(string); ok {
+			s.publications[normalizeUUID(publicationUUID)] = true
+			continue
+		}
+		if itemUUID, ok := arguments["uuid"].(string); ok {
+			s.items[normalizeUUID(itemUUID)] = true
+			continue
+		}
+		return &scope{all: true}
+	}
+	return s
+}
+
+// crossesPublications reports if selection set includes fields, which return items of any publications
+func crossesPublications(selectionSet ast.SelectionSet) bool {
+	for _, selection := range selectionSet {
+		var children ast.SelectionSet
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.ObjectDefinition != nil && crossPublicationFields[s.ObjectDefinition.Name+"."+s.Name] {
+				return true
+			}
+			children = s.SelectionSet
+		case *ast.InlineFragment:
+			children = s.SelectionSet
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				children = s.Definition.SelectionSet
+			}
+		}
+		if crossesPublications(children) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeUUID returns canonical form of UUID to match events, invalid UUID is returned as is and matches no items
+func normalizeUUID(value string) string {
+	if u, err := uuid.FromString(value); err == nil {
+		return u.String()
+	}
+	return value
+}
+
+// cacheKey returns hash of operation name, normalized query document and variables
+func cacheKey(oc *graphql.OperationContext) (string, error) {
+	variables, err := json.Marshal(oc.Variables)
+	if err != nil {
+		return "", err
+	}
+	var query bytes.Buffer
+	formatter.NewFormatter(&query).FormatQueryDocument(oc.Doc)
+	h := sha256.New()
+	h.Write([]byte(oc.OperationName))
+	h.Write([]byte{0})
+	h.Write(query.Bytes())
+	h.Write([]byte{0})
+	h.Write(variables)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
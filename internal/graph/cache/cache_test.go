@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func newQueryContext() context.Context {
+	return graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+		Doc:           &ast.QueryDocument{},
+		OperationName: "GetItems",
+		Operation:     &ast.OperationDefinition{Operation: ast.Query},
+	})
+}
+
+func newResponseCache(t *testing.T) *ResponseCache {
+	c, err := New(Config{Enabled: true, DefaultTTL: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCachedResponseIsCopied(t *testing.T) {
+	c := newResponseCache(t)
+	ctx := newQueryContext()
+	executions := 0
+	next := func(ctx context.Context) graphql.ResponseHandler {
+		executions++
+		return graphql.OneShot(&graphql.Response{Data: json.RawMessage(`{}`), Extensions: map[string]interface{}{"n": 1}})
+	}
+	first := c.InterceptOperation(ctx, next)(ctx)
+	first.Extensions["n"] = 2
+	second := c.InterceptOperation(ctx, next)(ctx)
+	if executions != 1 {
+		t.Fatalf("query is executed %d times, want 1", executions)
+	}
+	if second == first {
+		t.Fatal("cache hit returned the same response")
+	}
+	if second.Extensions["n"] != 1 {
+		t.Errorf("cached response is modified by previous caller: %v", second.Extensions)
+	}
+	second.Extensions["n"] = 3
+	if third := c.InterceptOperation(ctx, next)(ctx); third.Extensions["n"] != 1 {
+		t.Errorf("cached response is modified by cache hit caller: %v", third.Extensions)
+	}
+}
+
+func TestLeaderPanicReleasesWaiters(t *testing.T) {
+	c := newResponseCache(t)
+	ctx := newQueryContext()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	leader := func(ctx context.Context) graphql.ResponseHandler {
+		return func(ctx context.Context) *graphql.Response {
+			close(started)
+			<-release
+			panic("resolver failure")
+		}
+	}
+	go func() {
+		defer func() { recover() }()
+		c.InterceptOperation(ctx, leader)(ctx)
+	}()
+	<-started
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var response *graphql.Response
+	go func() {
+		defer wg.Done()
+		response = c.InterceptOperation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+			return graphql.OneShot(&graphql.Response{Data: json.RawMessage(`{}`)})
+		})(ctx)
+	}()
+	// Let waiter join in-flight query before leader panics
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiter is blocked after leader panic")
+	}
+	if response == nil {
+		t.Fatal("waiter got no response")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.inFlight) != 0 {
+		t.Errorf("in-flight query is not removed after leader panic")
+	}
+}
+
+var testSchema = gqlparser.MustLoadSchema(&ast.Source{Input: `
+type Item {
+    uuid: ID!
+    title: String!
+    relatedItems: [Item!]!
+}
+type Query {
+    items(publicationUUID: String): [Item]!
+    item(uuid: ID!): Item
+}`})
+
+func newParsedQueryContext(t *testing.T, query string) context.Context {
+	doc, errs := gqlparser.LoadQuery(testSchema, query)
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	return graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+		Doc:       doc,
+		Operation: doc.Operations[0],
+		Variables: map[string]interface{}{},
+	})
+}
+
+func TestItemEventInvalidatesAffectedResponses(t *testing.T) {
+	publicationUUID := uuid.Must(uuid.NewV4())
+	itemUUID := uuid.Must(uuid.NewV4())
+	queries := map[string]string{
+		"publication items":  `{ items(publicationUUID: "` + publicationUUID.String() + `") { title } }`,
+		"other publication":  `{ items(publicationUUID: "` + uuid.Must(uuid.NewV4()).String() + `") { title } }`,
+		"item":               `{ item(uuid: "` + itemUUID.String() + `") { title } }`,
+		"other item":         `{ item(uuid: "` + uuid.Must(uuid.NewV4()).String() + `") { title } }`,
+		"all items":          `{ items { title } }`,
+		"other item related": `{ item(uuid: "` + uuid.Must(uuid.NewV4()).String() + `") { relatedItems { title } } }`,
+	}
+	invalidated := map[string]bool{
+		"publication items":  true,
+		"item":               true,
+		"all items":          true,
+		"other item related": true,
+	}
+	c := newResponseCache(t)
+	executions := map[string]int{}
+	execute := func(name string) {
+		ctx := newParsedQueryContext(t, queries[name])
+		c.InterceptOperation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+			executions[name]++
+			return graphql.OneShot(&graphql.Response{Data: json.RawMessage(`{}`)})
+		})(ctx)
+	}
+	for name := range queries {
+		execute(name)
+	}
+	c.HandleItemEvent(&entity.ItemEvent{Operation: entity.ItemEventUpdate, UUID: itemUUID, PublicationUUID: publicationUUID})
+	for name := range queries {
+		execute(name)
+		want := 1
+		if invalidated[name] {
+			want = 2
+		}
+		if executions[name] != want {
+			t.Errorf("%s query is executed %d times, want %d", name, executions[name], want)
+		}
+	}
+
+	// Missed events invalidate all responses
+	c.HandleItemEvent(nil)
+	if execute("other publication"); executions["other publication"] != 2 {
+		t.Errorf("other publication query is executed %d times after missed events, want 2", executions["other publication"])
+	}
+}
@@ -0,0 +1,144 @@
+// Package hub distributes items added to repository to GraphQL subscriptions
+package hub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// subscriberBuffer is the number of items queued for subscriber, items are dropped for slow subscribers
+	subscriberBuffer = 16
+	// fetchTimeout limits fetching of added item from repository
+	fetchTimeout = 5 * time.Second
+)
+
+// ItemsRepository is used to fetch added items
+type ItemsRepository interface {
+	GetItemByUUID(context.Context, uuid.UUID) (*entity.Item, error)
+}
+
+// Filter selects items for subscriber, empty fields match any item
+type Filter struct {
+	PublicationUUID uuid.UUID
+	LanguageCode    string
+}
+
+func (f *Filter) matches(publicationUUID uuid.UUID, languageCode string) bool {
+	if f.PublicationUUID != uuid.Nil && f.PublicationUUID != publicationUUID {
+		return false
+	}
+	if f.LanguageCode != "" && f.LanguageCode != languageCode {
+		return false
+	}
+	return true
+}
+
+type subscriber struct {
+	filter Filter
+	items  chan *entity.Item
+}
+
+// Hub receives item events and sends added items to subscribers
+type Hub struct {
+	repository  ItemsRepository
+	logger      Logger
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// New creates hub
+func New(repository ItemsRepository, logger Logger) *Hub {
+	return &Hub{repository: repository, logger: logger, subscribers: map[*subscriber]struct{}{}}
+}
+
+// Subscribe returns channel of added items matching filter, channel is closed when context is done or hub is closed
+func (h *Hub) Subscribe(ctx context.Context, filter Filter) <-chan *entity.Item {
+	s := &subscriber{filter: filter, items: make(chan *entity.Item, subscriberBuffer)}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(s.items)
+		return s.items
+	}
+	h.subscribers[s] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		h.unsubscribe(s)
+	}()
+	return s.items
+}
+
+func (h *Hub) unsubscribe(s *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[s]; ok {
+		delete(h.subscribers, s)
+		close(s.items)
+	}
+}
+
+// HandleItemEvent sends inserted item to matching subscribers, item is fetched from repository only if there are any
+func (h *Hub) HandleItemEvent(event *entity.ItemEvent) {
+	if event == nil || event.Operation != entity.ItemEventInsert {
+		return
+	}
+	if !h.hasSubscribers(event) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	item, err := h.repository.GetItemByUUID(ctx, event.UUID)
+	if err != nil {
+		h.logger.Error("Failure fetching added item ", event.UUID, ": ", err)
+		return
+	}
+	if item == nil {
+		// Item is not valid anymore
+		return
+	}
+	h.Publish(item)
+}
+
+// Publish sends item to matching subscribers without blocking
+func (h *Hub) Publish(item *entity.Item) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subscribers {
+		if !s.filter.matches(item.PublicationUUID, item.LanguageCode) {
+			continue
+		}
+		select {
+		case s.items <- item:
+		default:
+			h.logger.Warn("Subscriber is too slow, dropping item ", item.UUID)
+		}
+	}
+}
+
+// Close ends all subscriptions
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for s := range h.subscribers {
+		delete(h.subscribers, s)
+		close(s.items)
+	}
+}
+
+func (h *Hub) hasSubscribers(event *entity.ItemEvent) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subscribers {
+		if s.filter.matches(event.PublicationUUID, event.LanguageCode) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+// Package metrics provides gqlgen handler extension, which records Prometheus metrics of operations and resolvers
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Operation name labels of operations without name and with names over the limit
+const (
+	anonymousOperation = "anonymous"
+	otherOperation     = "other"
+)
+
+// defaultMaxOperationNames is used when the limit of operation names is not configured
+const defaultMaxOperationNames = 100
+
+var (
+	operationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "naca_items",
+		Subsystem: "graphql",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of GraphQL operations by operation type, name ('other' over the names limit) and status: ok or error.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation_type", "operation_name", "status"})
+	resolverDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "naca_items",
+		Subsystem: "graphql",
+		Name:      "resolver_duration_seconds",
+		Help:      "Duration of GraphQL field resolvers by object, field and status: ok or error.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"object", "field", "status"})
+)
+
+// Config defines GraphQL metrics configuration
+type Config struct {
+	// OperationNames are always recorded, e.g. names of operations of known clients
+	OperationNames []string `mapstructure:"operation_names"`
+	// MaxOperationNames limits the number of other operation names, recorded in order they are seen.
+	// Names over the limit are recorded as "other"
+	MaxOperationNames int `mapstructure:"max_operation_names"`
+}
+
+// Metrics is gqlgen extension, which records durations of operations and resolvers.
+// Operation names are declared by clients in query documents, so the number of recorded names is limited
+// to keep the number of series bounded.
+type Metrics struct {
+	knownOperations   map[string]bool
+	maxOperationNames int
+
+	mu             sync.RWMutex
+	seenOperations map[string]bool
+}
+
+// New returns metrics extension. Configured operation names and knownOperations, e.g. names of persisted queries allowlist,
+// are always recorded and don't count to the limit.
+func New(config Config, knownOperations ...string) *Metrics {
+	m := &Metrics{
+		knownOperations:   map[string]bool{},
+		maxOperationNames: config.MaxOperationNames,
+		seenOperations:    map[string]bool{},
+	}
+	if m.maxOperationNames <= 0 {
+		m.maxOperationNames = defaultMaxOperationNames
+	}
+	for _, name := range append(config.OperationNames, knownOperations...) {
+		m.knownOperations[name] = true
+	}
+	return m
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+	graphql.FieldInterceptor
+} = &Metrics{}
+
+func (m *Metrics) ExtensionName() string {
+	return "Prometheus"
+}
+
+func (m *Metrics) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptOperation records duration of operation from the start of request till the response is ready.
+// Operations rejected during parsing, validation or limits checks are not intercepted.
+func (m *Metrics) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	operationType := string(oc.Operation.Operation)
+	// Name declared by executed operation, not the one requested
+	operationName := m.operationNameLabel(oc.Operation.Name)
+	responseHandler := next(ctx)
+	return func(ctx context.Context) *graphql.Response {
+		response := responseHandler(ctx)
+		if response == nil {
+			return nil
+		}
+		status := "ok"
+		if len(response.Errors) != 0 {
+			status = "error"
+		}
+		operationDuration.WithLabelValues(operationType, operationName, status).Observe(time.Since(oc.Stats.OperationStart).Seconds())
+		return response
+	}
+}
+
+// operationNameLabel returns operation name or its replacement, if the name is over the limit
+func (m *Metrics) operationNameLabel(name string) string {
+	if name == "" {
+		return anonymousOperation
+	}
+	if m.knownOperations[name] {
+		return name
+	}
+	m.mu.RLock()
+	seen, full := m.seenOperations[name], len(m.seenOperations) >= m.maxOperationNames
+	m.mu.RUnlock()
+	if seen {
+		return name
+	}
+	if full {
+		return otherOperation
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.seenOperations[name] && len(m.seenOperations) >= m.maxOperationNames {
+		return otherOperation
+	}
+	m.seenOperations[name] = true
+	return name
+}
+
+// InterceptField records duration of fields with resolvers, trivial struct fields are skipped
+func (m *Metrics) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if !fc.IsResolver {
+		return next(ctx)
+	}
+	start := time.Now()
+	res, err := next(ctx)
+	status := "ok"
+	if err != nil || len(graphql.GetFieldErrors(ctx, fc)) != 0 {
+		status = "error"
+	}
+	resolverDuration.WithLabelValues(fc.Object, fc.Field.Name, status).Observe(time.Since(start).Seconds())
+	return res, err
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import "testing"
+
+func TestOperationNameLabel(t *testing.T) {
+	m := New(Config{OperationNames: []string{"GetItems"}, MaxOperationNames: 2}, "GetItem")
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "", want: anonymousOperation},
+		{name: "GetItems", want: "GetItems"},
+		{name: "GetItem", want: "GetItem"},
+		{name: "SearchItems", want: "SearchItems"},
+		{name: "ItemsFeed", want: "ItemsFeed"},
+		// Limit of other names is reached
+		{name: "RandomClientName", want: otherOperation},
+		{name: "SearchItems", want: "SearchItems"},
+		{name: "GetItems", want: "GetItems"},
+	}
+	for _, tt := range tests {
+		if got := m.operationNameLabel(tt.name); got != tt.want {
+			t.Errorf("operationNameLabel(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOperationNameLabelDefaultLimit(t *testing.T) {
+	m := New(Config{})
+	for i := 0; i < defaultMaxOperationNames; i++ {
+		m.operationNameLabel(string(rune('a'+i%26)) + string(rune('0'+i/26)))
+	}
+	if got := m.operationNameLabel("GetItems"); got != otherOperation {
+		t.Errorf("operationNameLabel() over default limit = %q, want %q", got, otherOperation)
+	}
+}
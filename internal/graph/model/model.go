@@ -2,16 +2,21 @@ package model
 
 import (
 	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"time"
 
 	"github.com/Tarick/naca-items/internal/entity"
 	"github.com/gofrs/uuid"
 )
 
+// ItemsConnection holds single page of items
 type ItemsConnection struct {
-	Items []*entity.Item
-	// Slicing it from-to for pagination
-	FromIndex int
-	ToIndex   int
+	Items           []*entity.Item
+	HasNextPage     bool
+	HasPreviousPage bool
+	// Used to count all items for the connection
+	PublicationUUID uuid.UUID
 }
 type ItemsEdge struct {
 	Node   *entity.Item `json:"node"`
@@ -25,36 +30,121 @@ type PageInfo struct {
 	StartCursor     *string `json:"startCursor"`
 }
 
-func (i *ItemsConnection) TotalCount() int {
-	return len(i.Items)
-}
-
 // PageInfo returns PageInfo for paging with encoded cursors
 func (i *ItemsConnection) PageInfo() PageInfo {
-	var start, end string
+	pageInfo := PageInfo{
+		HasNextPage:     i.HasNextPage,
+		HasPreviousPage: i.HasPreviousPage,
+	}
 	if len(i.Items) > 0 {
-		start = EncodeCursor(i.Items[i.FromIndex].UUID)
-		end = EncodeCursor(i.Items[i.ToIndex].UUID)
+		start := EncodeCursor(entity.NewItemCursor(i.Items[0]))
+		end := EncodeCursor(entity.NewItemCursor(i.Items[len(i.Items)-1]))
+		pageInfo.StartCursor = &start
+		pageInfo.EndCursor = &end
+	}
+	return pageInfo
+}
+
+// StoryCluster holds ID of cluster of near-duplicate items, its items are fetched by resolver
+type StoryCluster struct {
+	ClusterID int64
+}
+
+// SearchItemsConnection holds single page of search results
+type SearchItemsConnection struct {
+	Results []*entity.ItemSearchResult
+	// Offset of the first result in the whole search results list
+	Offset      int
+	HasNextPage bool
+}
+
+type SearchItemsEdge struct {
+	Node       *entity.Item      `json:"node"`
+	Cursor     string            `json:"cursor"`
+	Rank       float64           `json:"rank"`
+	Highlights *SearchHighlights `json:"highlights"`
+}
+
+// PageInfo returns PageInfo for paging with encoded offset cursors
+func (c *SearchItemsConnection) PageInfo() PageInfo {
+	pageInfo := PageInfo{
+		HasNextPage:     c.HasNextPage,
+		HasPreviousPage: c.Offset > 0,
 	}
-	return PageInfo{
-		StartCursor:     &start,
-		EndCursor:       &end,
-		HasNextPage:     i.ToIndex < len(i.Items)-1,
-		HasPreviousPage: i.FromIndex > 0,
+	if len(c.Results) > 0 {
+		start := EncodeOffsetCursor(c.Offset + 1)
+		end := EncodeOffsetCursor(c.Offset + len(c.Results))
+		pageInfo.StartCursor = &start
+		pageInfo.EndCursor = &end
 	}
+	return pageInfo
 }
 
-// EncodeCursor creates base64 representation of UUID bytes array.
-// Manual decoding will not be readable, need to convert byte array to string
-func EncodeCursor(u uuid.UUID) string {
-	return base64.StdEncoding.EncodeToString(u.Bytes())
+// Edges returns search results with cursors
+func (c *SearchItemsConnection) Edges() []*SearchItemsEdge {
+	edges := make([]*SearchItemsEdge, len(c.Results))
+	for i, result := range c.Results {
+		edges[i] = &SearchItemsEdge{
+			Node:   result.Item,
+			Cursor: EncodeOffsetCursor(c.Offset + i + 1),
+			Rank:   result.Rank,
+			Highlights: &SearchHighlights{
+				Title:       result.TitleHighlight,
+				Description: result.DescriptionHighlight,
+				Content:     result.ContentHighlight,
+			},
+		}
+	}
+	return edges
+}
+
+// EncodeOffsetCursor creates base64 representation of position in the list.
+// Used where keyset pagination is not possible, e.g. for results ordered by rank
+func EncodeOffsetCursor(offset int) string {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, uint64(offset))
+	return base64.StdEncoding.EncodeToString(bytes)
+}
+
+// DecodeOffsetCursor decodes offset cursor
+func DecodeOffsetCursor(s string) (int, error) {
+	bytes, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	if len(bytes) != 8 {
+		return 0, fmt.Errorf("incorrect cursor length %d", len(bytes))
+	}
+	return int(binary.BigEndian.Uint64(bytes)), nil
+}
+
+// cursorLength is the size of encoded cursor: published date as Unix nanoseconds and UUID bytes
+const cursorLength = 8 + uuid.Size
+
+// EncodeCursor creates base64 representation of cursor published date and UUID bytes.
+// Manual decoding will not be readable, need to convert byte array to time and UUID
+func EncodeCursor(cursor *entity.ItemCursor) string {
+	bytes := make([]byte, cursorLength)
+	binary.BigEndian.PutUint64(bytes, uint64(cursor.PublishedDate.UnixNano()))
+	copy(bytes[8:], cursor.UUID.Bytes())
+	return base64.StdEncoding.EncodeToString(bytes)
 }
 
 // DecodeCursor decodes cursor
-func DecodeCursor(s string) (uuid.UUID, error) {
+func DecodeCursor(s string) (*entity.ItemCursor, error) {
 	bytes, err := base64.StdEncoding.DecodeString(s)
 	if err != nil {
-		return uuid.Nil, err
+		return nil, err
+	}
+	if len(bytes) != cursorLength {
+		return nil, fmt.Errorf("incorrect cursor length %d", len(bytes))
+	}
+	u, err := uuid.FromBytes(bytes[8:])
+	if err != nil {
+		return nil, err
 	}
-	return uuid.FromBytes(bytes)
+	return &entity.ItemCursor{
+		PublishedDate: time.Unix(0, int64(binary.BigEndian.Uint64(bytes))),
+		UUID:          u,
+	}, nil
 }
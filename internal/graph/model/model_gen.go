@@ -0,0 +1,36 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+type CreateItemPayload struct {
+	Item   *entity.Item  `json:"item"`
+	Errors []*FieldError `json:"errors"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ItemInput struct {
+	PublicationUUID string    `json:"publicationUUID"`
+	PublishedDate   time.Time `json:"publishedDate"`
+	Title           string    `json:"title"`
+	Description     *string   `json:"description"`
+	Content         *string   `json:"content"`
+	URL             *string   `json:"url"`
+	LanguageCode    *string   `json:"languageCode"`
+	GUID            *string   `json:"guid"`
+}
+
+type SearchHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+}
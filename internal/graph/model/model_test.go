@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor *entity.ItemCursor
+	}{
+		{"nanoseconds", &entity.ItemCursor{PublishedDate: time.Date(2021, 1, 10, 12, 30, 15, 123456789, time.UTC), UUID: uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))}},
+		{"before epoch", &entity.ItemCursor{PublishedDate: time.Date(1960, 5, 1, 0, 0, 0, 0, time.UTC), UUID: uuid.Must(uuid.NewV4())}},
+		{"nil uuid", &entity.ItemCursor{PublishedDate: time.Unix(0, 0).UTC(), UUID: uuid.Nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(EncodeCursor(tt.cursor))
+			if err != nil {
+				t.Fatalf("DecodeCursor() failed: %v", err)
+			}
+			if !got.PublishedDate.Equal(tt.cursor.PublishedDate) || got.UUID != tt.cursor.UUID {
+				t.Errorf("DecodeCursor() = %v, want %v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty", ""},
+		{"not base64", "not a cursor!"},
+		{"offset cursor", EncodeOffsetCursor(10)},
+		{"too long", EncodeCursor(&entity.ItemCursor{}) + "AAAA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := DecodeCursor(tt.cursor); err == nil {
+				t.Errorf("DecodeCursor(%q) = %v, want error", tt.cursor, got)
+			}
+		})
+	}
+}
+
+func TestOffsetCursorRoundTrip(t *testing.T) {
+	for _, offset := range []int{0, 1, 20, 1 << 40} {
+		got, err := DecodeOffsetCursor(EncodeOffsetCursor(offset))
+		if err != nil {
+			t.Fatalf("DecodeOffsetCursor() failed: %v", err)
+		}
+		if got != offset {
+			t.Errorf("DecodeOffsetCursor() = %d, want %d", got, offset)
+		}
+	}
+	if _, err := DecodeOffsetCursor(EncodeCursor(&entity.ItemCursor{})); err == nil {
+		t.Error("DecodeOffsetCursor() of item cursor succeeded, want error")
+	}
+}
@@ -0,0 +1,121 @@
+package persisted
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const errOperationNotAllowed = "OPERATION_NOT_ALLOWED"
+
+// Allowlist is the set of preregistered queries by their SHA256 hashes.
+// Used as gqlgen extension it rejects operations, which are not in the set.
+type Allowlist struct {
+	queries map[string]string
+	// operationNames are names of operations in allowlisted queries
+	operationNames []string
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = &Allowlist{}
+
+// LoadAllowlist reads JSON file with map of query hashes to queries, hashes are verified
+func LoadAllowlist(path string) (*Allowlist, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	queries := map[string]string{}
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("failure parsing allowlist %s: %w", path, err)
+	}
+	names := map[string]bool{}
+	for hash, query := range queries {
+		if Hash(query) != hash {
+			return nil, fmt.Errorf("allowlist %s: hash %s doesn't match its query", path, hash)
+		}
+		document, err := parser.ParseQuery(&ast.Source{Input: query})
+		if err != nil {
+			return nil, fmt.Errorf("allowlist %s: failure parsing query %s: %w", path, hash, err)
+		}
+		for _, operation := range document.Operations {
+			if operation.Name != "" {
+				names[operation.Name] = true
+			}
+		}
+	}
+	operationNames := make([]string, 0, len(names))
+	for name := range names {
+		operationNames = append(operationNames, name)
+	}
+	sort.Strings(operationNames)
+	return &Allowlist{queries: queries, operationNames: operationNames}, nil
+}
+
+// Len returns number of allowlisted queries
+func (a *Allowlist) Len() int {
+	return len(a.queries)
+}
+
+// OperationNames returns sorted names of operations in allowlisted queries, nil allowlist has none
+func (a *Allowlist) OperationNames() []string {
+	if a == nil {
+		return nil
+	}
+	return a.operationNames
+}
+
+// Preload adds allowlisted queries to APQ cache, so clients can send hashes only from the first request
+func (a *Allowlist) Preload(ctx context.Context, cache graphql.Cache) {
+	for hash, query := range a.queries {
+		cache.Add(ctx, hash, query)
+	}
+}
+
+func (a *Allowlist) ExtensionName() string {
+	return "Allowlist"
+}
+
+func (a *Allowlist) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationParameters accepts requests with allowlisted query or APQ hash of it, hash only requests get query from allowlist
+func (a *Allowlist) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	hash := persistedQueryHash(rawParams.Extensions)
+	if rawParams.Query != "" {
+		queryHash := Hash(rawParams.Query)
+		if hash != "" && hash != queryHash {
+			return gqlerror.Errorf("provided APQ hash does not match query")
+		}
+		hash = queryHash
+	}
+	query, ok := a.queries[hash]
+	if !ok {
+		err := gqlerror.Errorf("operation is not in allowlist")
+		errcode.Set(err, errOperationNotAllowed)
+		return err
+	}
+	rawParams.Query = query
+	return nil
+}
+
+// persistedQueryHash returns hash from APQ extension of request, if any
+func persistedQueryHash(extensions map[string]interface{}) string {
+	persistedQuery, ok := extensions["persistedQuery"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	hash, _ := persistedQuery["sha256Hash"].(string)
+	return hash
+}
@@ -0,0 +1,51 @@
+// Package persisted provides storage of persisted queries for Automatic Persisted Queries and
+// strict allowlist of operations, which rejects queries not registered in advance
+package persisted
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+)
+
+// CacheMemory is in-process LRU cache, it isn't shared between API instances
+const CacheMemory = "memory"
+
+// defaultCacheSize is used when cache size is not configured
+const defaultCacheSize = 1000
+
+// Config defines persisted queries configuration
+type Config struct {
+	// Cache is the type of APQ cache, only "memory" is supported, empty means "memory"
+	Cache string `mapstructure:"cache"`
+	// CacheSize is the max number of queries in memory cache
+	CacheSize int `mapstructure:"cache_size"`
+	// AllowlistFile is the path to JSON file with map of query SHA256 hashes to queries.
+	// In non strict mode allowlisted queries are preloaded to APQ cache.
+	AllowlistFile string `mapstructure:"allowlist_file"`
+	// Strict mode accepts only allowlisted queries, APQ registration of new queries is disabled
+	Strict bool `mapstructure:"strict"`
+}
+
+// NewCache creates APQ cache of configured type
+func NewCache(config Config) (graphql.Cache, error) {
+	switch config.Cache {
+	case "", CacheMemory:
+		size := config.CacheSize
+		if size <= 0 {
+			size = defaultCacheSize
+		}
+		return lru.New(size), nil
+	default:
+		return nil, fmt.Errorf("unsupported persisted queries cache type %q", config.Cache)
+	}
+}
+
+// Hash returns hex encoded SHA256 of query, as used by APQ clients
+func Hash(query string) string {
+	b := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(b[:])
+}
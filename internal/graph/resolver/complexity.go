@@ -0,0 +1,58 @@
+package resolver
+
+import "github.com/Tarick/naca-items/internal/graph/generated"
+
+// unpaginatedListSize is the estimated number of items returned by queries without page size, used in complexity calculation
+const unpaginatedListSize = 100
+
+// NewComplexityRoot returns complexity functions for list fields, multiplying child complexity by requested page size
+func NewComplexityRoot() generated.ComplexityRoot {
+	c := generated.ComplexityRoot{}
+	c.Query.Items = func(childComplexity int, publicationUUID *string, orderAsc *bool) int {
+		return childComplexity * unpaginatedListSize
+	}
+	c.Query.ItemsConnection = func(childComplexity int, publicationUUID *string, orderAsc *bool, first *int, after *string, last *int, before *string) int {
+		pageSize := defaultItemsPageSize
+		if first != nil {
+			pageSize = *first
+		} else if last != nil {
+			pageSize = *last
+		}
+		if pageSize > maxItemsPageSize {
+			pageSize = maxItemsPageSize
+		}
+		if pageSize < 1 {
+			pageSize = 1
+		}
+		return childComplexity * pageSize
+	}
+	c.Query.SearchItems = func(childComplexity int, query string, languageCode *string, publicationUUID *string, first *int, after *string) int {
+		pageSize := defaultSearchPageSize
+		if first != nil && *first > 0 {
+			pageSize = *first
+		}
+		if pageSize > maxSearchPageSize {
+			pageSize = maxSearchPageSize
+		}
+		return childComplexity * pageSize
+	}
+	c.Item.RelatedItems = func(childComplexity int, first *int) int {
+		return childComplexity * clusterComplexityPageSize(first)
+	}
+	c.StoryCluster.Items = func(childComplexity int, first *int) int {
+		return childComplexity * clusterComplexityPageSize(first)
+	}
+	return c
+}
+
+// clusterComplexityPageSize returns requested number of story cluster items, clamped as in clusterPageSize
+func clusterComplexityPageSize(first *int) int {
+	pageSize := maxClusterPageSize
+	if first != nil && *first >= 0 && *first < maxClusterPageSize {
+		pageSize = *first
+	}
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	return pageSize
+}
@@ -2,9 +2,17 @@ package resolver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/internal/graph/hub"
+	"github.com/Tarick/naca-items/internal/graph/model"
+	"github.com/Tarick/naca-items/internal/processor"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/opentracing/opentracing-go"
+	otLog "github.com/opentracing/opentracing-go/log"
 	// Rename to uuidImpl since uuid is masked in functions - used with gqlgen code generation
 	"github.com/gofrs/uuid"
 	uuidImpl "github.com/gofrs/uuid"
@@ -12,9 +20,30 @@ import (
 
 //go:generate go run github.com/99designs/gqlgen
 
+const (
+	// defaultItemsPageSize is used when neither first nor last items are requested from items connection
+	defaultItemsPageSize = 20
+	maxItemsPageSize     = 100
+	// defaultSearchPageSize is used when search page size is not requested
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+	// maxClusterPageSize limits story cluster items and related items
+	maxClusterPageSize = 100
+)
+
 // Resolver uses dependency injection
 type Resolver struct {
 	ItemsRepository ItemsRepository
+	ItemsHub        ItemsHub
+	// Identities select item UUID strategy of publication, title and date are used if it is nil
+	Identities *entity.IdentityStrategies
+	// Stages prepare created items the same way as worker processing does
+	Stages []processor.Stage
+}
+
+// ItemsHub provides added items to subscriptions
+type ItemsHub interface {
+	Subscribe(context.Context, hub.Filter) <-chan *entity.Item
 }
 
 // ItemsRepository is the interface for repository implementation
@@ -23,15 +52,122 @@ type ItemsRepository interface {
 	GetItems(context.Context) ([]*entity.Item, error)
 	GetItemsByPublicationUUID(context.Context, uuidImpl.UUID) ([]*entity.Item, error)
 	GetItemsByPublicationUUIDSortByPublishedDate(context.Context, uuidImpl.UUID, bool) ([]*entity.Item, error)
+	GetItemsPageByPublicationUUID(ctx context.Context, publicationUUID uuidImpl.UUID, sortAsc bool, after *entity.ItemCursor, before *entity.ItemCursor, limit int, fromEnd bool) ([]*entity.Item, error)
+	CountItemsByPublicationUUID(context.Context, uuidImpl.UUID) (int, error)
+	Create(context.Context, *entity.Item) error
+	ChangeItemState(context.Context, *entity.ItemStateTransition) (*entity.ItemStateTransition, error)
+	SearchItems(context.Context, *entity.ItemSearchQuery) ([]*entity.ItemSearchResult, error)
+	GetItemsByClusterID(ctx context.Context, clusterID int64, limit int) ([]*entity.Item, error)
+	LinkStoryCluster(context.Context, *entity.Item) (int64, error)
 	// Needed to healthcheck
 	Healthcheck(context.Context) error
 }
 
-func getItemIndexByUUID(arr []*entity.Item, id uuidImpl.UUID) (int, error) {
-	for i := range arr {
-		if arr[i].UUID == id {
-			return i, nil
+// changeItemState requests item state transition, reporting not found items and items already in requested state as errors
+func (r *Resolver) changeItemState(ctx context.Context, itemUUID string, state entity.ItemState, reason string, actor string) (*entity.ItemStateTransition, error) {
+	UUID, err := uuidImpl.FromString(itemUUID)
+	if err != nil {
+		return nil, err
+	}
+	request := entity.NewItemStateTransition(UUID, state, reason, actor)
+	if err := request.Validate(); err != nil {
+		return nil, processor.NewValidationError(err)
+	}
+	transition, err := r.ItemsRepository.ChangeItemState(ctx, request)
+	if err != nil {
+		return nil, processor.NewError(processor.ErrRepositoryUnavailable, err)
+	}
+	if transition == nil {
+		return nil, processor.NewError(processor.ErrItemNotFound, fmt.Errorf("item %s not found", UUID))
+	}
+	if !transition.Changed() {
+		return nil, processor.NewError(processor.ErrItemStateUnchanged, fmt.Errorf("item %s is already in state '%s'", UUID, state))
+	}
+	return transition, nil
+}
+
+// linkStoryCluster links created item with its near-duplicates, as worker processing does.
+// Item is already stored, so failure is only recorded in trace and item stays out of cluster.
+func (r *Resolver) linkStoryCluster(ctx context.Context, item *entity.Item) {
+	if item.Fingerprint == 0 {
+		return
+	}
+	if _, err := r.ItemsRepository.LinkStoryCluster(ctx, item); err != nil {
+		if span := opentracing.SpanFromContext(ctx); span != nil {
+			span.LogFields(otLog.Error(fmt.Errorf("failure linking item %s to story cluster: %w", item.UUID, err)))
+		}
+	}
+}
+
+// clusterPageSize validates requested number of story cluster items
+func clusterPageSize(first *int) (int, error) {
+	if first == nil {
+		return maxClusterPageSize, nil
+	}
+	if *first < 0 || *first > maxClusterPageSize {
+		return 0, fmt.Errorf("'first' parameter must be between 0 and %d", maxClusterPageSize)
+	}
+	return *first, nil
+}
+
+// itemInputFields maps entity.ItemCore json field names, used in validation errors, to ItemInput field names
+var itemInputFields = map[string]string{
+	"publication_uuid": "publicationUUID",
+	"published_date":   "publishedDate",
+	"title":            "title",
+	"description":      "description",
+	"content":          "content",
+	"url":              "url",
+	"language_code":    "languageCode",
+	"guid":             "guid",
+}
+
+// newItemCoreFromInput creates and validates ItemCore from input, returning per field validation errors
+func newItemCoreFromInput(input model.ItemInput) (*entity.ItemCore, []*model.FieldError) {
+	publicationUUID, err := uuidImpl.FromString(input.PublicationUUID)
+	if err != nil {
+		return nil, []*model.FieldError{{Field: "publicationUUID", Message: "must be a valid UUID"}}
+	}
+	itemCore := entity.NewItemCore()
+	itemCore.PublicationUUID = publicationUUID
+	itemCore.PublishedDate = input.PublishedDate
+	itemCore.Title = input.Title
+	if input.LanguageCode != nil {
+		itemCore.LanguageCode = *input.LanguageCode
+	}
+	if input.Description != nil {
+		itemCore.Description = *input.Description
+	}
+	if input.Content != nil {
+		itemCore.Content = *input.Content
+	}
+	if input.URL != nil {
+		itemCore.URL = *input.URL
+	}
+	if input.GUID != nil {
+		itemCore.GUID = *input.GUID
+	}
+	// Language code could be filled by processing stages, the prepared item is validated completely
+	if err := itemCore.ValidateSubmitted(); err != nil {
+		return nil, newFieldErrors(err, itemInputFields)
+	}
+	return itemCore, nil
+}
+
+// newFieldErrors converts validation errors to field errors, sorted by field name.
+// fieldNames maps validated struct field names to GraphQL ones.
+func newFieldErrors(err error, fieldNames map[string]string) []*model.FieldError {
+	var validationErrors validation.Errors
+	if !errors.As(err, &validationErrors) {
+		return []*model.FieldError{{Field: "", Message: err.Error()}}
+	}
+	fieldErrors := make([]*model.FieldError, 0, len(validationErrors))
+	for field, fieldErr := range validationErrors {
+		if name, ok := fieldNames[field]; ok {
+			field = name
 		}
+		fieldErrors = append(fieldErrors, &model.FieldError{Field: field, Message: fieldErr.Error()})
 	}
-	return 0, fmt.Errorf("didn't find element %s in array", id)
+	sort.Slice(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+	return fieldErrors
 }
@@ -0,0 +1,129 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/internal/graph/model"
+	"github.com/Tarick/naca-items/internal/graph/resolver"
+	"github.com/Tarick/naca-items/internal/processor"
+	"github.com/Tarick/naca-items/internal/processor/stages"
+	"github.com/Tarick/naca-items/internal/repository/memory"
+	"github.com/gofrs/uuid"
+)
+
+func newTestResolver(t *testing.T) *resolver.Resolver {
+	processingStages, err := stages.New(stages.Config{
+		SanitizeHTML:    stages.SanitizeHTMLConfig{Enabled: true},
+		Normalize:       stages.NormalizeConfig{Enabled: true},
+		DetectLanguage:  stages.DetectLanguageConfig{Enabled: true},
+		CanonicalizeURL: stages.CanonicalizeURLConfig{Enabled: true},
+	}, entity.NewURLCanonicalizer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &resolver.Resolver{ItemsRepository: memory.New(), Stages: processingStages}
+}
+
+func newTestItemInput(url string) model.ItemInput {
+	content := `<p>Council   approved the budget</p><script>alert(1)</script>`
+	languageCode := "en"
+	return model.ItemInput{
+		PublicationUUID: uuid.Must(uuid.NewV4()).String(),
+		PublishedDate:   time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		Title:           "  Budget   approved ",
+		Content:         &content,
+		URL:             &url,
+		LanguageCode:    &languageCode,
+	}
+}
+
+func TestCreateItemPreparesItem(t *testing.T) {
+	r := newTestResolver(t)
+	payload, err := r.Mutation().CreateItem(context.Background(), newTestItemInput("http://Example.com/news/1?utm_source=feed"))
+	if err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if len(payload.Errors) > 0 {
+		t.Fatalf("CreateItem() field errors = %+v", payload.Errors[0])
+	}
+	stored, err := r.ItemsRepository.GetItemByUUID(context.Background(), payload.Item.UUID)
+	if err != nil || stored == nil {
+		t.Fatalf("GetItemByUUID() = %v, %v", stored, err)
+	}
+	if want := "Budget approved"; stored.Title != want {
+		t.Errorf("Title = %q, want %q", stored.Title, want)
+	}
+	if want := "<p>Council approved the budget</p>"; stored.Content != want {
+		t.Errorf("Content = %q, want %q", stored.Content, want)
+	}
+	if want := "https://example.com/news/1"; stored.CanonicalURL != want {
+		t.Errorf("CanonicalURL = %q, want %q", stored.CanonicalURL, want)
+	}
+}
+
+func TestCreateItemRejectsUnprocessableItem(t *testing.T) {
+	r := newTestResolver(t)
+	// Submitted title is long enough, but not after whitespace is collapsed by normalization
+	input := newTestItemInput("http://example.com/news/1")
+	input.Title = "  a    b  "
+	payload, err := r.Mutation().CreateItem(context.Background(), input)
+	if err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if len(payload.Errors) == 0 || payload.Item != nil {
+		t.Errorf("CreateItem() = %+v, want field errors", payload)
+	}
+}
+
+func TestCreateItemWithoutLanguage(t *testing.T) {
+	r := newTestResolver(t)
+	input := newTestItemInput("https://example.com/news/2")
+	input.LanguageCode = nil
+	content := "<p>The city council approved the new budget on Tuesday evening after a long debate about public transport.</p>"
+	input.Content = &content
+	payload, err := r.Mutation().CreateItem(context.Background(), input)
+	if err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if len(payload.Errors) > 0 {
+		t.Fatalf("CreateItem() field errors = %+v", payload.Errors[0])
+	}
+	if want := "en"; payload.Item.LanguageCode != want {
+		t.Errorf("LanguageCode = %q, want detected %q", payload.Item.LanguageCode, want)
+	}
+
+	// Language is required, if it couldn't be detected
+	input = newTestItemInput("https://example.com/news/3")
+	input.LanguageCode = nil
+	input.Content = nil
+	payload, err = r.Mutation().CreateItem(context.Background(), input)
+	if err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if len(payload.Errors) != 1 || payload.Errors[0].Field != "languageCode" {
+		t.Errorf("CreateItem() field errors = %+v, want languageCode error", payload.Errors)
+	}
+}
+
+func TestChangeItemStateReportsUnchangedState(t *testing.T) {
+	r := newTestResolver(t)
+	payload, err := r.Mutation().CreateItem(context.Background(), newTestItemInput("https://example.com/news/4"))
+	if err != nil || len(payload.Errors) > 0 {
+		t.Fatalf("CreateItem() = %+v, %v", payload, err)
+	}
+	itemUUID := payload.Item.UUID.String()
+	if _, err := r.Mutation().DisableItem(context.Background(), itemUUID, "spam", "moderator"); err != nil {
+		t.Fatalf("DisableItem() error = %v", err)
+	}
+	_, err = r.Mutation().DisableItem(context.Background(), itemUUID, "spam", "moderator")
+	if !errors.Is(err, processor.ErrItemStateUnchanged) {
+		t.Errorf("DisableItem() of disabled item error = %v, want %v", err, processor.ErrItemStateUnchanged)
+	}
+	if processor.IsRetryable(err) {
+		t.Errorf("IsRetryable(%v) = true, want false", err)
+	}
+}
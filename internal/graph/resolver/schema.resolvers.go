@@ -5,11 +5,15 @@ package resolver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/Tarick/naca-items/internal/entity"
 	"github.com/Tarick/naca-items/internal/graph/generated"
+	"github.com/Tarick/naca-items/internal/graph/hub"
 	"github.com/Tarick/naca-items/internal/graph/model"
+	"github.com/Tarick/naca-items/internal/processor"
 	uuidImpl "github.com/gofrs/uuid"
 )
 
@@ -21,17 +25,94 @@ func (r *itemResolver) PublicationUUID(ctx context.Context, obj *entity.Item) (s
 	return obj.PublicationUUID.String(), nil
 }
 
+func (r *itemResolver) Cluster(ctx context.Context, obj *entity.Item) (*model.StoryCluster, error) {
+	if obj.ClusterID == 0 {
+		return nil, nil
+	}
+	return &model.StoryCluster{ClusterID: obj.ClusterID}, nil
+}
+
+func (r *itemResolver) RelatedItems(ctx context.Context, obj *entity.Item, first *int) ([]*entity.Item, error) {
+	limit, err := clusterPageSize(first)
+	if err != nil {
+		return nil, err
+	}
+	related := []*entity.Item{}
+	if obj.ClusterID == 0 || limit == 0 {
+		return related, nil
+	}
+	// Item itself is in the cluster too
+	items, err := r.ItemsRepository.GetItemsByClusterID(ctx, obj.ClusterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.UUID != obj.UUID && len(related) < limit {
+			related = append(related, item)
+		}
+	}
+	return related, nil
+}
+
+func (r *itemStateTransitionResolver) ItemUUID(ctx context.Context, obj *entity.ItemStateTransition) (string, error) {
+	return obj.ItemUUID.String(), nil
+}
+
+func (r *itemStateTransitionResolver) FromState(ctx context.Context, obj *entity.ItemStateTransition) (string, error) {
+	return string(obj.FromState), nil
+}
+
+func (r *itemStateTransitionResolver) ToState(ctx context.Context, obj *entity.ItemStateTransition) (string, error) {
+	return string(obj.ToState), nil
+}
+
+func (r *itemsConnectionResolver) TotalCount(ctx context.Context, obj *model.ItemsConnection) (*int, error) {
+	count, err := r.ItemsRepository.CountItemsByPublicationUUID(ctx, obj.PublicationUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
 func (r *itemsConnectionResolver) Edges(ctx context.Context, obj *model.ItemsConnection) ([]*model.ItemsEdge, error) {
-	edges := make([]*model.ItemsEdge, obj.ToIndex-obj.FromIndex+1)
-	for i := range edges {
+	edges := make([]*model.ItemsEdge, len(obj.Items))
+	for i, item := range obj.Items {
 		edges[i] = &model.ItemsEdge{
-			Node:   obj.Items[obj.FromIndex+i],
-			Cursor: model.EncodeCursor(obj.Items[obj.FromIndex+i].UUID),
+			Node:   item,
+			Cursor: model.EncodeCursor(entity.NewItemCursor(item)),
 		}
 	}
 	return edges, nil
 }
 
+func (r *mutationResolver) CreateItem(ctx context.Context, input model.ItemInput) (*model.CreateItemPayload, error) {
+	itemCore, fieldErrors := newItemCoreFromInput(input)
+	if len(fieldErrors) > 0 {
+		return &model.CreateItemPayload{Errors: fieldErrors}, nil
+	}
+	item := r.Identities.NewItem(itemCore)
+	if err := processor.PrepareItem(ctx, item, r.Stages...); err != nil {
+		return &model.CreateItemPayload{Errors: newFieldErrors(err, itemInputFields)}, nil
+	}
+	err := r.ItemsRepository.Create(ctx, item)
+	if errors.Is(err, entity.ErrItemExists) {
+		return nil, processor.NewError(processor.ErrDuplicateItem, fmt.Errorf("item %s already exists", item.UUID))
+	}
+	if err != nil {
+		return nil, processor.NewError(processor.ErrRepositoryUnavailable, err)
+	}
+	r.linkStoryCluster(ctx, item)
+	return &model.CreateItemPayload{Item: item, Errors: []*model.FieldError{}}, nil
+}
+
+func (r *mutationResolver) DisableItem(ctx context.Context, uuid string, reason string, actor string) (*entity.ItemStateTransition, error) {
+	return r.changeItemState(ctx, uuid, entity.ItemStateDisabled, reason, actor)
+}
+
+func (r *mutationResolver) RestoreItem(ctx context.Context, uuid string, reason string, actor string) (*entity.ItemStateTransition, error) {
+	return r.changeItemState(ctx, uuid, entity.ItemStateValid, reason, actor)
+}
+
 func (r *queryResolver) Items(ctx context.Context, publicationUUID *string, orderAsc *bool) ([]*entity.Item, error) {
 	if publicationUUID == nil {
 		return r.ItemsRepository.GetItems(ctx)
@@ -40,103 +121,190 @@ func (r *queryResolver) Items(ctx context.Context, publicationUUID *string, orde
 		if err != nil {
 			return nil, err
 		}
-		return r.ItemsRepository.GetItemsByPublicationUUIDSortByPublishedDate(ctx, publUUID, *orderAsc)
+		return r.ItemsRepository.GetItemsByPublicationUUIDSortByPublishedDate(ctx, publUUID, orderAsc != nil && *orderAsc)
 	}
 }
 
 func (r *queryResolver) ItemsConnection(ctx context.Context, publicationUUID *string, orderAsc *bool, first *int, after *string, last *int, before *string) (*model.ItemsConnection, error) {
-	publUUID, err := uuidImpl.FromString(*publicationUUID)
-	if err != nil {
-		return nil, err
+	if publicationUUID == nil {
+		return nil, fmt.Errorf("'publicationUUID' parameter is required")
 	}
-	fetchedItems, err := r.ItemsRepository.GetItemsByPublicationUUIDSortByPublishedDate(ctx, publUUID, *orderAsc)
+	publUUID, err := uuidImpl.FromString(*publicationUUID)
 	if err != nil {
 		return nil, err
 	}
-	if len(fetchedItems) == 0 {
-		return &model.ItemsConnection{
-			Items:     fetchedItems,
-			FromIndex: 0,
-			ToIndex:   0,
-		}, nil
-	}
-	// Default slicing - the whole slice
-	fromIndex := 0
-	// Last item UUID
-	toIndex := len(fetchedItems) - 1
+	var afterCursor, beforeCursor *entity.ItemCursor
 	if after != nil {
-		// from := fetchedItems[fromIndex].UUID
-		from, err := model.DecodeCursor(*after)
-		if err != nil {
+		if afterCursor, err = model.DecodeCursor(*after); err != nil {
 			return nil, err
 		}
-		fromIndex, err = getItemIndexByUUID(fetchedItems, from)
-		if err != nil {
-			return nil, err
-		}
-		// with 'after', we return the next element
-		fromIndex++
 	}
 	if before != nil {
-		to, err := model.DecodeCursor(*before)
-		if err != nil {
+		if beforeCursor, err = model.DecodeCursor(*before); err != nil {
 			return nil, err
 		}
-		toIndex, err = getItemIndexByUUID(fetchedItems, to)
+	}
+	if first != nil && (*first < 0 || *first > maxItemsPageSize) {
+		return nil, fmt.Errorf("'first' parameter must be between 0 and %d", maxItemsPageSize)
+	}
+	if last != nil && (*last < 0 || *last > maxItemsPageSize) {
+		return nil, fmt.Errorf("'last' parameter must be between 0 and %d", maxItemsPageSize)
+	}
+	if first == nil && last == nil {
+		pageSize := defaultItemsPageSize
+		first = &pageSize
+	}
+	// Fetch one more item than requested to find out if there is the next (or previous) page
+	limit, fromEnd := 0, false
+	if first != nil {
+		limit = *first + 1
+	} else {
+		limit, fromEnd = *last+1, true
+	}
+	items, err := r.ItemsRepository.GetItemsPageByPublicationUUID(ctx, publUUID, orderAsc != nil && *orderAsc, afterCursor, beforeCursor, limit, fromEnd)
+	if err != nil {
+		return nil, err
+	}
+	connection := &model.ItemsConnection{PublicationUUID: publUUID}
+	if first != nil {
+		if len(items) > *first {
+			items = items[:*first]
+			connection.HasNextPage = true
+		}
+		if last != nil && len(items) > *last {
+			items = items[len(items)-*last:]
+			connection.HasPreviousPage = true
+		} else if last == nil {
+			// Cursor points to existing item, so there are elements before it
+			connection.HasPreviousPage = afterCursor != nil
+		}
+	} else {
+		if len(items) > *last {
+			items = items[1:]
+			connection.HasPreviousPage = true
+		}
+		connection.HasNextPage = beforeCursor != nil
+	}
+	connection.Items = items
+	return connection, nil
+}
+
+func (r *queryResolver) Item(ctx context.Context, uuid string) (*entity.Item, error) {
+	UUID, err := uuidImpl.FromString(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return r.ItemsRepository.GetItemByUUID(ctx, UUID)
+}
+
+func (r *queryResolver) SearchItems(ctx context.Context, query string, languageCode *string, publicationUUID *string, first *int, after *string) (*model.SearchItemsConnection, error) {
+	searchQuery := &entity.ItemSearchQuery{Query: query, Limit: defaultSearchPageSize}
+	if languageCode != nil {
+		searchQuery.LanguageCode = *languageCode
+	}
+	if publicationUUID != nil {
+		publUUID, err := uuidImpl.FromString(*publicationUUID)
 		if err != nil {
 			return nil, err
 		}
-		// with 'before', we return the previous elements
-		toIndex--
-		if fromIndex > toIndex {
-			return nil, fmt.Errorf("'before' and 'after' cursors yielded negative slice of elements, incorrect cursors or changed order assumed")
-		}
+		searchQuery.PublicationUUID = publUUID
 	}
 	if first != nil {
-		if *first < 0 {
-			return nil, fmt.Errorf("'first' parameter cannot be negative")
-		}
-		toFirstIndex := fromIndex + *first - 1
-		if toFirstIndex < toIndex {
-			toIndex = toFirstIndex
+		if *first < 0 || *first > maxSearchPageSize {
+			return nil, fmt.Errorf("'first' parameter must be between 0 and %d", maxSearchPageSize)
 		}
+		searchQuery.Limit = *first
 	}
-	if last != nil {
-		if *last < 0 {
-			return nil, fmt.Errorf("'last' parameter cannot be negative")
-		}
-		fromLastIndex := toIndex - *last + 1
-		if fromLastIndex > fromIndex {
-			fromIndex = fromLastIndex
+	if after != nil {
+		offset, err := model.DecodeOffsetCursor(*after)
+		if err != nil {
+			return nil, err
 		}
+		searchQuery.Offset = offset
 	}
+	connection := &model.SearchItemsConnection{Offset: searchQuery.Offset, Results: []*entity.ItemSearchResult{}}
+	if searchQuery.Limit == 0 {
+		return connection, nil
+	}
+	// Fetch one more result to find out if there is the next page
+	searchQuery.Limit++
+	if err := searchQuery.Validate(); err != nil {
+		return nil, err
+	}
+	results, err := r.ItemsRepository.SearchItems(ctx, searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) >= searchQuery.Limit {
+		results = results[:searchQuery.Limit-1]
+		connection.HasNextPage = true
+	}
+	connection.Results = results
+	return connection, nil
+}
 
-	return &model.ItemsConnection{
-		Items:     fetchedItems,
-		FromIndex: fromIndex,
-		ToIndex:   toIndex,
-	}, nil
+func (r *storyClusterResolver) ID(ctx context.Context, obj *model.StoryCluster) (string, error) {
+	return strconv.FormatInt(obj.ClusterID, 10), nil
 }
 
-func (r *queryResolver) Item(ctx context.Context, uuid string) (*entity.Item, error) {
-	UUID, err := uuidImpl.FromString(uuid)
+func (r *storyClusterResolver) Items(ctx context.Context, obj *model.StoryCluster, first *int) ([]*entity.Item, error) {
+	limit, err := clusterPageSize(first)
 	if err != nil {
 		return nil, err
 	}
-	return r.ItemsRepository.GetItemByUUID(ctx, UUID)
+	if limit == 0 {
+		return []*entity.Item{}, nil
+	}
+	return r.ItemsRepository.GetItemsByClusterID(ctx, obj.ClusterID, limit)
+}
+
+func (r *subscriptionResolver) ItemAdded(ctx context.Context, publicationUUID *string, languageCode *string) (<-chan *entity.Item, error) {
+	if r.ItemsHub == nil {
+		return nil, fmt.Errorf("subscriptions are not available")
+	}
+	filter := hub.Filter{}
+	if publicationUUID != nil {
+		publUUID, err := uuidImpl.FromString(*publicationUUID)
+		if err != nil {
+			return nil, err
+		}
+		filter.PublicationUUID = publUUID
+	}
+	if languageCode != nil {
+		filter.LanguageCode = *languageCode
+	}
+	return r.ItemsHub.Subscribe(ctx, filter), nil
 }
 
 // Item returns generated.ItemResolver implementation.
 func (r *Resolver) Item() generated.ItemResolver { return &itemResolver{r} }
 
+// ItemStateTransition returns generated.ItemStateTransitionResolver implementation.
+func (r *Resolver) ItemStateTransition() generated.ItemStateTransitionResolver {
+	return &itemStateTransitionResolver{r}
+}
+
 // ItemsConnection returns generated.ItemsConnectionResolver implementation.
 func (r *Resolver) ItemsConnection() generated.ItemsConnectionResolver {
 	return &itemsConnectionResolver{r}
 }
 
+// Mutation returns generated.MutationResolver implementation.
+func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// StoryCluster returns generated.StoryClusterResolver implementation.
+func (r *Resolver) StoryCluster() generated.StoryClusterResolver { return &storyClusterResolver{r} }
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type itemResolver struct{ *Resolver }
+type itemStateTransitionResolver struct{ *Resolver }
 type itemsConnectionResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type storyClusterResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
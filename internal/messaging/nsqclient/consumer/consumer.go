@@ -1,6 +1,11 @@
 package consumer
 
 import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/messaging/nsqclient/producer"
 	"github.com/nsqio/go-nsq"
 )
 
@@ -12,6 +17,20 @@ type MessageConsumerConfig struct {
 	Prefetch  int    `mapstructure:"prefetch"`
 	Workers   int    `mapstructure:"workers"`
 	Attempts  uint16 `mapstructure:"attempts"`
+	// Messages, which processing failed permanently, are published to dead letter topic. Empty topic disables it.
+	DeadLetter producer.MessageProducerConfig `mapstructure:"dead_letter"`
+}
+
+// DeadLetterMessage wraps message, that could not be processed, with the failure details
+type DeadLetterMessage struct {
+	MessageID string    `json:"message_id"`
+	Topic     string    `json:"topic"`
+	Channel   string    `json:"channel"`
+	Body      []byte    `json:"body"`
+	Error     string    `json:"error"`
+	Attempts  uint16    `json:"attempts"`
+	Timestamp time.Time `json:"timestamp"`
+	FailedAt  time.Time `json:"failed_at"`
 }
 
 // Logger interface
@@ -28,16 +47,35 @@ type MessageProcessor interface {
 	Process([]byte) error
 }
 
+// MessagePublisher is used to publish dead letter messages
+type MessagePublisher interface {
+	Publish([]byte) error
+	Stop()
+}
+
+// retryable is implemented by classified processing errors, it reports if retrying the message could succeed
+type retryable interface {
+	IsRetryable() bool
+}
+
 type messageHandler struct {
-	processor MessageProcessor
-	logger    Logger
+	processor  MessageProcessor
+	logger     Logger
+	deadLetter MessagePublisher
+	topic      string
+	channel    string
 }
 
 // HandleMessage implements the Handler interface.
 func (h *messageHandler) HandleMessage(m *nsq.Message) error {
+	inFlight := messagesInFlight.WithLabelValues(h.topic, h.channel)
+	inFlight.Inc()
+	defer inFlight.Dec()
+	messageAttempts.WithLabelValues(h.topic, h.channel).Observe(float64(m.Attempts))
 	if len(m.Body) == 0 {
 		// Returning nil will automatically send a FIN command to NSQ to mark the message as processed.
 		h.logger.Debug("Message ", m.ID, " received with empty body")
+		h.countResult(resultFinished)
 		return nil
 	}
 
@@ -45,10 +83,65 @@ func (h *messageHandler) HandleMessage(m *nsq.Message) error {
 	err := h.processor.Process(m.Body)
 	if err != nil {
 		h.logger.Error("Failure processing message with ID: ", m.ID, "error: ", err)
+		var retryableErr retryable
+		if errors.As(err, &retryableErr) && !retryableErr.IsRetryable() {
+			// Message is finished, unless it couldn't be dead lettered
+			if err := h.sendToDeadLetter(m, err); err != nil {
+				h.countResult(resultRequeued)
+				return err
+			}
+			return nil
+		}
 		// Returning a non-nil error will automatically send a REQ command to NSQ to re-queue the message.
-		//TODO: handle errors that should not cause reschedule
+		h.countResult(resultRequeued)
 		return err
 	}
+	h.countResult(resultFinished)
+	return nil
+}
+
+func (h *messageHandler) countResult(result string) {
+	messagesHandled.WithLabelValues(h.topic, h.channel, result).Inc()
+}
+
+// LogFailedMessage implements nsq.FailedMessageLogger, it is called for message that exceeded max attempts before it is finished
+func (h *messageHandler) LogFailedMessage(m *nsq.Message) {
+	h.logger.Error("Message ", m.ID, " exceeded max attempts number ", m.Attempts)
+	if err := h.sendToDeadLetter(m, errors.New("exceeded max attempts")); err != nil {
+		h.logger.Error("Message ", m.ID, " is dropped")
+		h.countResult(resultDropped)
+	}
+}
+
+// sendToDeadLetter publishes message with failure details to dead letter topic.
+// Without dead letter topic configured, message is dropped.
+func (h *messageHandler) sendToDeadLetter(m *nsq.Message, failure error) error {
+	if h.deadLetter == nil {
+		h.logger.Warn("Dead letter topic is not configured, dropping message ", m.ID)
+		h.countResult(resultDropped)
+		return nil
+	}
+	deadLetterMessage := DeadLetterMessage{
+		MessageID: string(m.ID[:]),
+		Topic:     h.topic,
+		Channel:   h.channel,
+		Body:      m.Body,
+		Error:     failure.Error(),
+		Attempts:  m.Attempts,
+		Timestamp: time.Unix(0, m.Timestamp),
+		FailedAt:  time.Now(),
+	}
+	bytes, err := json.Marshal(deadLetterMessage)
+	if err != nil {
+		h.logger.Error("Failure marshalling dead letter message for ", m.ID, ": ", err)
+		return err
+	}
+	if err := h.deadLetter.Publish(bytes); err != nil {
+		h.logger.Error("Failure publishing message ", m.ID, " to dead letter topic: ", err)
+		return err
+	}
+	h.logger.Info("Message ", m.ID, " is published to dead letter topic")
+	h.countResult(resultDeadLettered)
 	return nil
 }
 
@@ -68,9 +161,13 @@ func (c *messageConsumer) Start() error {
 	return c.consumer.ConnectToNSQLookupd(c.nsqLookupdHost)
 }
 
-// Stop stops consumer
+// Stop stops consumer and blocks until in-flight messages are handled, then stops dead letter producer
 func (c *messageConsumer) Stop() {
 	c.consumer.Stop()
+	<-c.consumer.StopChan
+	if c.handler.deadLetter != nil {
+		c.handler.deadLetter.Stop()
+	}
 }
 
 func New(config *MessageConsumerConfig, processor MessageProcessor, logger Logger) (*messageConsumer, error) {
@@ -83,10 +180,22 @@ func New(config *MessageConsumerConfig, processor MessageProcessor, logger Logge
 	}
 	// consumer.SetLogger(log, )
 	handler := &messageHandler{
-		processor,
-		logger,
+		processor: processor,
+		logger:    logger,
+		topic:     config.Topic,
+		channel:   config.Channel,
+	}
+	if config.DeadLetter.Topic != "" {
+		deadLetter, err := producer.New(&config.DeadLetter)
+		if err != nil {
+			return nil, err
+		}
+		handler.deadLetter = deadLetter
 	}
 	consumer.AddConcurrentHandlers(handler, config.Workers)
+	if err := registerConnectionsGauge(consumer, config.Topic, config.Channel); err != nil {
+		return nil, err
+	}
 
 	return &messageConsumer{consumer: consumer, nsqLookupdHost: config.NSQLookup, handler: handler, logger: logger}, nil
 }
@@ -0,0 +1,50 @@
+package consumer
+
+import (
+	"github.com/nsqio/go-nsq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	messageAttempts = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "naca_items",
+		Subsystem: "consumer",
+		Name:      "message_attempts",
+		Help:      "Number of delivery attempts of received messages.",
+		Buckets:   prometheus.LinearBuckets(1, 1, 10),
+	}, []string{"topic", "channel"})
+	messagesInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "naca_items",
+		Subsystem: "consumer",
+		Name:      "messages_in_flight",
+		Help:      "Number of messages being handled.",
+	}, []string{"topic", "channel"})
+	messagesHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "naca_items",
+		Subsystem: "consumer",
+		Name:      "messages_total",
+		Help:      "Number of handled messages by result: finished, requeued, dead_lettered or dropped.",
+	}, []string{"topic", "channel", "result"})
+)
+
+// Results of message handling
+const (
+	resultFinished     = "finished"
+	resultRequeued     = "requeued"
+	resultDeadLettered = "dead_lettered"
+	resultDropped      = "dropped"
+)
+
+// registerConnectionsGauge exports number of consumer connections to nsqd, zero means consuming is stalled
+func registerConnectionsGauge(consumer *nsq.Consumer, topic, channel string) error {
+	return prometheus.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   "naca_items",
+		Subsystem:   "consumer",
+		Name:        "connections",
+		Help:        "Number of consumer connections to nsqd.",
+		ConstLabels: prometheus.Labels{"topic": topic, "channel": channel},
+	}, func() float64 {
+		return float64(consumer.Stats().Connections)
+	}))
+}
@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"github.com/Tarick/naca-items/pkg/itemevents"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	eventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "naca_items",
+		Subsystem: "outbox",
+		Name:      "events_published_total",
+		Help:      "Number of item events published from outbox by event type.",
+	}, []string{"type"})
+	relayErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "naca_items",
+		Subsystem: "outbox",
+		Name:      "relay_errors_total",
+		Help:      "Number of failed outbox relay runs, failed events are published on the next run.",
+	})
+)
+
+// eventTypeLabel returns metrics label for event type
+func eventTypeLabel(eventType itemevents.EventType) string {
+	switch eventType {
+	case itemevents.ItemCreatedType:
+		return "item_created"
+	case itemevents.ItemUpdatedType:
+		return "item_updated"
+	case itemevents.ItemStateChangedType:
+		return "item_state_changed"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,93 @@
+// Package outbox relays item events, written by repository to outbox together with items changes, to NSQ.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Tarick/naca-items/pkg/itemevents"
+)
+
+// Config defines outbox relay configuration
+type Config struct {
+	Host  string `mapstructure:"host"`
+	Topic string `mapstructure:"topic"`
+	// PollInterval is the pause in seconds between outbox reads, when outbox is drained
+	PollInterval int `mapstructure:"poll_interval"`
+	// BatchSize is the maximum number of events claimed for publishing at once
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// Repository reads and removes published events from outbox
+type Repository interface {
+	PublishOutboxEvents(ctx context.Context, limit int, publish func(*itemevents.EventEnvelope) error) (int, error)
+}
+
+// Publisher sends event to NSQ topic
+type Publisher interface {
+	Publish(body []byte) error
+}
+
+// Relay publishes outbox events at least once, in the order they were written.
+// Order of events, including events of the same item, is not guaranteed if several relays run concurrently.
+type Relay struct {
+	repository   Repository
+	publisher    Publisher
+	logger       Logger
+	pollInterval time.Duration
+	batchSize    int
+}
+
+// New creates relay
+func New(config Config, repository Repository, publisher Publisher, logger Logger) *Relay {
+	relay := &Relay{
+		repository:   repository,
+		publisher:    publisher,
+		logger:       logger,
+		pollInterval: time.Duration(config.PollInterval) * time.Second,
+		batchSize:    config.BatchSize,
+	}
+	if relay.pollInterval <= 0 {
+		relay.pollInterval = time.Second
+	}
+	if relay.batchSize <= 0 {
+		relay.batchSize = 100
+	}
+	return relay
+}
+
+// Run polls outbox and publishes events until ctx is cancelled.
+// Full batch means there are more events, so the next one is read without waiting.
+func (r *Relay) Run(ctx context.Context) error {
+	r.logger.Info("Starting outbox relay")
+	for {
+		published, err := r.repository.PublishOutboxEvents(ctx, r.batchSize, r.publish)
+		if err != nil && ctx.Err() == nil {
+			relayErrors.Inc()
+			r.logger.Error("Outbox relay failed after publishing ", published, " events: ", err)
+		}
+		if err == nil && published == r.batchSize && ctx.Err() == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Stopped outbox relay")
+			return nil
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+func (r *Relay) publish(event *itemevents.EventEnvelope) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := r.publisher.Publish(body); err != nil {
+		return err
+	}
+	eventsPublished.WithLabelValues(eventTypeLabel(event.Type)).Inc()
+	r.logger.Debug("Published outbox event ", event.ID, " of type ", event.Type)
+	return nil
+}
@@ -0,0 +1,123 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+	otLog "github.com/opentracing/opentracing-go/log"
+)
+
+// ItemOutcome defines result of processing of single item in batch
+type ItemOutcome string
+
+const (
+	// OutcomeCreated is the outcome of item added to repository
+	OutcomeCreated ItemOutcome = "created"
+	// OutcomeDuplicate is the outcome of item, which already exists in repository or earlier in batch
+	OutcomeDuplicate ItemOutcome = "duplicate"
+	// OutcomeInvalid is the outcome of item, that failed validation
+	OutcomeInvalid ItemOutcome = "invalid"
+)
+
+// BatchItemResult reports outcome of processing of item with Index position in batch.
+// Err is set for invalid and duplicate items.
+type BatchItemResult struct {
+	Index   int
+	UUID    uuid.UUID
+	Outcome ItemOutcome
+	Err     error
+}
+
+// ProcessNewItemsBatch passes items through processing stages, validates and adds batch of items to the system with a single repository call.
+// Invalid and duplicate items are skipped, their outcomes are reported in results.
+// Error is returned only if batch couldn't be stored.
+func (p *processor) ProcessNewItemsBatch(ctx context.Context, itemCores []*entity.ItemCore) ([]*BatchItemResult, error) {
+	span, ctx := p.setupTracingSpan(ctx, "create-new-items-batch")
+	defer span.Finish()
+	span.SetTag("batch.size", len(itemCores))
+
+	results := make([]*BatchItemResult, len(itemCores))
+	items := []*entity.Item{}
+	// Item UUID to its first position in batch
+	positions := map[uuid.UUID]int{}
+	for i, itemCore := range itemCores {
+		results[i] = &BatchItemResult{Index: i}
+		if itemCore == nil {
+			results[i].Outcome, results[i].Err = OutcomeInvalid, NewError(ErrMalformedMessage, fmt.Errorf("item %d is empty", i))
+			continue
+		}
+		item := p.identities.NewItem(itemCore)
+		if err := PrepareItem(ctx, item, p.stages...); err != nil {
+			results[i].Outcome, results[i].Err = OutcomeInvalid, err
+			continue
+		}
+		results[i].UUID = item.UUID
+		if position, ok := positions[item.UUID]; ok {
+			results[i].Outcome, results[i].Err = OutcomeDuplicate, NewError(ErrDuplicateItem, fmt.Errorf("item %s is a duplicate of item %d in batch", item.UUID, position))
+			continue
+		}
+		positions[item.UUID] = i
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		recordBatchResults(itemCores, results)
+		return results, nil
+	}
+	created, err := p.repository.CreateItems(ctx, items)
+	if err != nil {
+		span.LogFields(
+			otLog.Error(err),
+		)
+		for _, item := range items {
+			recordItem(NewItemsBatchType, OutcomeFailed, item.PublicationUUID)
+		}
+		return nil, NewError(ErrRepositoryUnavailable, err)
+	}
+	createdUUIDs := make(map[uuid.UUID]bool, len(created))
+	for _, u := range created {
+		createdUUIDs[u] = true
+	}
+	for _, item := range items {
+		result := results[positions[item.UUID]]
+		if createdUUIDs[item.UUID] {
+			result.Outcome = OutcomeCreated
+			// Items are linked one by one, so near-duplicates within batch are linked too
+			p.linkStoryCluster(ctx, item)
+		} else {
+			result.Outcome, result.Err = OutcomeDuplicate, NewError(ErrDuplicateItem, fmt.Errorf("item %s already exists", item.UUID))
+		}
+	}
+	span.LogFields(
+		otLog.Int("createdNumber", len(created)),
+	)
+	recordBatchResults(itemCores, results)
+	return results, nil
+}
+
+// recordBatchResults counts batch items by outcome
+func recordBatchResults(itemCores []*entity.ItemCore, results []*BatchItemResult) {
+	for i, result := range results {
+		publicationUUID := uuid.Nil
+		if itemCores[i] != nil {
+			publicationUUID = itemCores[i].PublicationUUID
+		}
+		recordItem(NewItemsBatchType, result.Outcome, publicationUUID)
+	}
+}
+
+// logBatchResults logs invalid items and summary of batch processing
+func (p *processor) logBatchResults(results []*BatchItemResult) {
+	outcomes := map[ItemOutcome]int{}
+	for _, result := range results {
+		outcomes[result.Outcome]++
+		if result.Outcome == OutcomeInvalid {
+			p.logger.Error("Skipped invalid item ", result.Index, " in batch: ", result.Err)
+		}
+	}
+	p.logger.Info("Processed batch of ", len(results), " items: ",
+		outcomes[OutcomeCreated], " created, ",
+		outcomes[OutcomeDuplicate], " duplicate, ",
+		outcomes[OutcomeInvalid], " invalid")
+}
@@ -0,0 +1,48 @@
+package processor
+
+import (
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+// URLCanonicalizationConfig defines URL canonicalization rules, usable for Viper. See entity.URLCanonicalizer.
+// The same rules must be used by API and worker, since canonical_url identity strategy depends on them.
+type URLCanonicalizationConfig struct {
+	HTTPS             bool `mapstructure:"https"`
+	LowercaseHost     bool `mapstructure:"lowercase_host"`
+	RemoveWWW         bool `mapstructure:"remove_www"`
+	RemoveDefaultPort bool `mapstructure:"remove_default_port"`
+	RemoveFragment    bool `mapstructure:"remove_fragment"`
+	SortQuery         bool `mapstructure:"sort_query"`
+	// RemoveParams replaces default list of removed tracking and session parameters, see entity.DefaultRemovedURLParams
+	RemoveParams []string `mapstructure:"remove_params"`
+}
+
+// NewURLCanonicalizationConfig returns configuration with default rules, configured values override them
+func NewURLCanonicalizationConfig() URLCanonicalizationConfig {
+	c := entity.NewURLCanonicalizer()
+	return URLCanonicalizationConfig{
+		HTTPS:             c.HTTPS,
+		LowercaseHost:     c.LowercaseHost,
+		RemoveWWW:         c.RemoveWWW,
+		RemoveDefaultPort: c.RemoveDefaultPort,
+		RemoveFragment:    c.RemoveFragment,
+		SortQuery:         c.SortQuery,
+	}
+}
+
+// NewURLCanonicalizer creates URL canonicalizer from configuration
+func NewURLCanonicalizer(config URLCanonicalizationConfig) *entity.URLCanonicalizer {
+	removeParams := config.RemoveParams
+	if len(removeParams) == 0 {
+		removeParams = entity.DefaultRemovedURLParams
+	}
+	return &entity.URLCanonicalizer{
+		HTTPS:             config.HTTPS,
+		LowercaseHost:     config.LowercaseHost,
+		RemoveWWW:         config.RemoveWWW,
+		RemoveDefaultPort: config.RemoveDefaultPort,
+		RemoveFragment:    config.RemoveFragment,
+		SortQuery:         config.SortQuery,
+		RemoveParams:      removeParams,
+	}
+}
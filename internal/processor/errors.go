@@ -0,0 +1,142 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Error classes of processing failures, use errors.Is to match them
+var (
+	// ErrMalformedMessage is the class of messages, that couldn't be decoded
+	ErrMalformedMessage = errors.New("malformed message")
+	// ErrUnknownMessageType is the class of messages with undefined MessageType
+	ErrUnknownMessageType = errors.New("unknown message type")
+	// ErrValidation is the class of messages with invalid content, see ValidationError for details
+	ErrValidation = errors.New("validation failed")
+	// ErrDuplicateItem is the class of attempts to add already existing item. Duplicate item of single item message
+	// is a redelivered or already processed message, so it is skipped without error, see CreateItem
+	ErrDuplicateItem = errors.New("item already exists")
+	// ErrItemNotFound is the class of operations on missing item
+	ErrItemNotFound = errors.New("item not found")
+	// ErrItemStateUnchanged is the class of state transitions of item already in requested state
+	ErrItemStateUnchanged = errors.New("item is already in requested state")
+	// ErrRepositoryUnavailable is the class of failures to read or write data in repository
+	ErrRepositoryUnavailable = errors.New("repository unavailable")
+)
+
+// Error is the processing failure of some class, it matches the class with errors.Is and wraps the cause
+type Error struct {
+	Class error
+	Err   error
+}
+
+// NewError creates error of the class with err as the cause
+func NewError(class error, err error) *Error {
+	return &Error{Class: class, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Class.Error()
+	}
+	return fmt.Sprint(e.Class, ": ", e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches error class
+func (e *Error) Is(target error) bool {
+	return target == e.Class
+}
+
+// IsRetryable reports if the same operation could succeed later
+func (e *Error) IsRetryable() bool {
+	return e.Class == ErrRepositoryUnavailable
+}
+
+// ValidationError is the failure of fields validation, it has ErrValidation class.
+// Fields maps field name to the validation failure description.
+type ValidationError struct {
+	Fields map[string]string
+	Err    error
+}
+
+// NewValidationError creates ValidationError, filling fields details from ozzo-validation errors
+func NewValidationError(err error) *ValidationError {
+	validationErr := &ValidationError{Fields: map[string]string{}, Err: err}
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		for field, fieldErr := range fieldErrors {
+			validationErr.Fields[field] = fieldErr.Error()
+		}
+	}
+	return validationErr
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprint(ErrValidation, ": ", e.Err)
+	}
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	message := ErrValidation.Error() + ":"
+	for _, field := range fields {
+		message += fmt.Sprintf(" %s: %s;", field, e.Fields[field])
+	}
+	return message
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrValidation class
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// IsRetryable reports if the same operation could succeed later, invalid data never becomes valid
+func (e *ValidationError) IsRetryable() bool {
+	return false
+}
+
+// errorClasses lists all known classes
+var errorClasses = []error{
+	ErrMalformedMessage,
+	ErrUnknownMessageType,
+	ErrValidation,
+	ErrDuplicateItem,
+	ErrItemNotFound,
+	ErrItemStateUnchanged,
+	ErrRepositoryUnavailable,
+}
+
+// ErrorClass returns the class of err or nil, if err is not classified
+func ErrorClass(err error) error {
+	for _, class := range errorClasses {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+	return nil
+}
+
+// IsRetryable reports if operation failed with err could succeed later.
+// Unclassified errors are considered retryable.
+func IsRetryable(err error) bool {
+	var classified interface {
+		IsRetryable() bool
+	}
+	if errors.As(err, &classified) {
+		return classified.IsRetryable()
+	}
+	return true
+}
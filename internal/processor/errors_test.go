@@ -0,0 +1,64 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func TestErrorClassification(t *testing.T) {
+	cause := errors.New("cause")
+	tests := []struct {
+		name      string
+		err       error
+		class     error
+		retryable bool
+	}{
+		{"malformed message", NewError(ErrMalformedMessage, cause), ErrMalformedMessage, false},
+		{"unknown message type", NewError(ErrUnknownMessageType, cause), ErrUnknownMessageType, false},
+		{"validation", NewValidationError(validation.Errors{"title": errors.New("cannot be blank")}), ErrValidation, false},
+		{"duplicate item", NewError(ErrDuplicateItem, cause), ErrDuplicateItem, false},
+		{"item not found", NewError(ErrItemNotFound, cause), ErrItemNotFound, false},
+		{"item state unchanged", NewError(ErrItemStateUnchanged, cause), ErrItemStateUnchanged, false},
+		{"repository unavailable", NewError(ErrRepositoryUnavailable, cause), ErrRepositoryUnavailable, true},
+		{"wrapped repository unavailable", fmt.Errorf("processing: %w", NewError(ErrRepositoryUnavailable, cause)), ErrRepositoryUnavailable, true},
+		{"wrapped validation", fmt.Errorf("processing: %w", NewValidationError(cause)), ErrValidation, false},
+		{"unclassified", cause, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorClass(tt.err); got != tt.class {
+				t.Errorf("ErrorClass() = %v, want %v", got, tt.class)
+			}
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.retryable)
+			}
+		})
+	}
+}
+
+func TestErrorUnwrapsCause(t *testing.T) {
+	cause := errors.New("cause")
+	if err := NewError(ErrRepositoryUnavailable, cause); !errors.Is(err, cause) {
+		t.Errorf("errors.Is(%v, cause) = false, want true", err)
+	}
+	if err := NewValidationError(cause); !errors.Is(err, cause) {
+		t.Errorf("errors.Is(%v, cause) = false, want true", err)
+	}
+}
+
+func TestNewValidationErrorFields(t *testing.T) {
+	err := NewValidationError(validation.Errors{
+		"title":         errors.New("cannot be blank"),
+		"language_code": errors.New("the length must be exactly 2"),
+	})
+	if len(err.Fields) != 2 || err.Fields["title"] != "cannot be blank" {
+		t.Errorf("Fields = %v, want title and language_code failures", err.Fields)
+	}
+	want := "validation failed: language_code: the length must be exactly 2; title: cannot be blank;"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
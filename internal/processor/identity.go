@@ -0,0 +1,38 @@
+package processor
+
+import (
+	"fmt"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+// IdentityConfig defines item identity strategies, usable for Viper.
+// Strategies are title_date (default), guid, url and canonical_url, see entity.IdentityStrategy.
+type IdentityConfig struct {
+	Default string `mapstructure:"default"`
+	// Publications maps publication UUID to its strategy
+	Publications map[string]string `mapstructure:"publications"`
+}
+
+// NewIdentityStrategies creates identity strategies from configuration, canonical_url strategy uses canonicalizer rules
+func NewIdentityStrategies(config IdentityConfig, canonicalizer *entity.URLCanonicalizer) (*entity.IdentityStrategies, error) {
+	defaultStrategy, err := entity.NewIdentityStrategy(config.Default, canonicalizer)
+	if err != nil {
+		return nil, err
+	}
+	strategies := &entity.IdentityStrategies{
+		Default:      defaultStrategy,
+		Publications: make(map[uuid.UUID]entity.IdentityStrategy, len(config.Publications)),
+	}
+	for publication, name := range config.Publications {
+		publicationUUID, err := uuid.FromString(publication)
+		if err != nil {
+			return nil, fmt.Errorf("publication %q: %w", publication, err)
+		}
+		if strategies.Publications[publicationUUID], err = entity.NewIdentityStrategy(name, canonicalizer); err != nil {
+			return nil, fmt.Errorf("publication %s: %w", publicationUUID, err)
+		}
+	}
+	return strategies, nil
+}
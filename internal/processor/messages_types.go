@@ -1,6 +1,8 @@
 package processor
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Tarick/naca-items/internal/entity"
@@ -10,6 +12,14 @@ import (
 const (
 	// NewItemType is the metadata for messages that defines the body of message as new incoming item
 	NewItemType MessageType = iota
+	// UpdateItemType is the metadata for messages that defines the body of message as updated version of already ingested item
+	UpdateItemType
+	// DisableItemType is the metadata for messages that request to disable (hide) item
+	DisableItemType
+	// EnableItemType is the metadata for messages that request to restore disabled item
+	EnableItemType
+	// NewItemsBatchType is the metadata for messages that define the body of message as the list of new incoming items
+	NewItemsBatchType
 )
 
 // MessageType defines types of messages
@@ -32,6 +42,24 @@ type NewItemBody struct {
 	*entity.ItemCore
 }
 
+//NewItemsBatchBody defines New Items Batch message body
+type NewItemsBatchBody struct {
+	Items []*entity.ItemCore `json:"items"`
+}
+
+//UpdateItemBody defines Update Item message body.
+// Item is identified the same way as new item - by PublicationUUID and fields of publication identity strategy, other fields are updated.
+type UpdateItemBody struct {
+	*entity.ItemCore
+}
+
+//ItemStateBody defines body of Disable Item and Enable Item messages
+type ItemStateBody struct {
+	UUID   uuid.UUID `json:"uuid"`
+	Reason string    `json:"reason"`
+	Actor  string    `json:"actor"`
+}
+
 //NewItemMessageEnvelope creates message envelope with message type and basic item
 func NewItemMessageEnvelope(
 	metadata map[string]string,
@@ -43,6 +71,69 @@ func NewItemMessageEnvelope(
 	languageCode string,
 	publishedDate time.Time) (*MessageEnvelope, error) {
 
+	itemCore, err := newValidItemCore(publicationUUID, title, description, content, url, languageCode, publishedDate)
+	if err != nil {
+		return &MessageEnvelope{}, err
+	}
+
+	return &MessageEnvelope{
+		Type:     NewItemType,
+		Msg:      NewItemBody{itemCore},
+		Metadata: metadata,
+	}, nil
+}
+
+//NewItemsBatchMessageEnvelope creates message envelope with batch message type and list of items.
+// All items must be valid.
+func NewItemsBatchMessageEnvelope(metadata map[string]string, itemCores []*entity.ItemCore) (*MessageEnvelope, error) {
+	if len(itemCores) == 0 {
+		return &MessageEnvelope{}, errors.New("batch of items is empty")
+	}
+	for i, itemCore := range itemCores {
+		if err := itemCore.ValidateSubmitted(); err != nil {
+			return &MessageEnvelope{}, fmt.Errorf("item %d is invalid: %w", i, err)
+		}
+	}
+	return &MessageEnvelope{
+		Type:     NewItemsBatchType,
+		Msg:      NewItemsBatchBody{Items: itemCores},
+		Metadata: metadata,
+	}, nil
+}
+
+//UpdateItemMessageEnvelope creates message envelope with update message type and item
+func UpdateItemMessageEnvelope(
+	metadata map[string]string,
+	publicationUUID uuid.UUID,
+	title string,
+	description string,
+	content string,
+	url string,
+	languageCode string,
+	publishedDate time.Time) (*MessageEnvelope, error) {
+
+	itemCore, err := newValidItemCore(publicationUUID, title, description, content, url, languageCode, publishedDate)
+	if err != nil {
+		return &MessageEnvelope{}, err
+	}
+
+	return &MessageEnvelope{
+		Type:     UpdateItemType,
+		Msg:      UpdateItemBody{itemCore},
+		Metadata: metadata,
+	}, nil
+}
+
+// newValidItemCore fills and validates ItemCore. Language code could be empty, it is detected by processing.
+func newValidItemCore(
+	publicationUUID uuid.UUID,
+	title string,
+	description string,
+	content string,
+	url string,
+	languageCode string,
+	publishedDate time.Time) (*entity.ItemCore, error) {
+
 	itemCore := entity.NewItemCore()
 	itemCore.PublicationUUID = publicationUUID
 	itemCore.PublishedDate = publishedDate
@@ -51,13 +142,29 @@ func NewItemMessageEnvelope(
 	itemCore.Content = content
 	itemCore.URL = url
 	itemCore.LanguageCode = languageCode
-	if err := itemCore.Validate(); err != nil {
-		return &MessageEnvelope{}, err
+	if err := itemCore.ValidateSubmitted(); err != nil {
+		return nil, err
 	}
+	return itemCore, nil
+}
+
+//DisableItemMessageEnvelope creates message envelope to disable item
+func DisableItemMessageEnvelope(metadata map[string]string, itemUUID uuid.UUID, reason string, actor string) (*MessageEnvelope, error) {
+	return itemStateMessageEnvelope(DisableItemType, entity.ItemStateDisabled, metadata, itemUUID, reason, actor)
+}
+
+//EnableItemMessageEnvelope creates message envelope to restore disabled item
+func EnableItemMessageEnvelope(metadata map[string]string, itemUUID uuid.UUID, reason string, actor string) (*MessageEnvelope, error) {
+	return itemStateMessageEnvelope(EnableItemType, entity.ItemStateValid, metadata, itemUUID, reason, actor)
+}
 
+func itemStateMessageEnvelope(messageType MessageType, state entity.ItemState, metadata map[string]string, itemUUID uuid.UUID, reason string, actor string) (*MessageEnvelope, error) {
+	if err := entity.NewItemStateTransition(itemUUID, state, reason, actor).Validate(); err != nil {
+		return &MessageEnvelope{}, err
+	}
 	return &MessageEnvelope{
-		Type:     NewItemType,
-		Msg:      NewItemBody{itemCore},
+		Type:     messageType,
+		Msg:      ItemStateBody{UUID: itemUUID, Reason: reason, Actor: actor},
 		Metadata: metadata,
 	}, nil
 }
@@ -0,0 +1,59 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+func newTestItemCore(languageCode string) *entity.ItemCore {
+	itemCore := entity.NewItemCore()
+	itemCore.PublicationUUID = uuid.Must(uuid.NewV4())
+	itemCore.PublishedDate = time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	itemCore.Title = "Breaking news"
+	itemCore.URL = "https://example.com/news/1"
+	itemCore.LanguageCode = languageCode
+	return itemCore
+}
+
+func TestItemEnvelopesLanguageCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		languageCode string
+		wantErr      bool
+	}{
+		{name: "valid language", languageCode: "en"},
+		{name: "missing language is detected by processing", languageCode: ""},
+		{name: "invalid language", languageCode: "english", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestItemCore(tt.languageCode)
+			_, err := NewItemMessageEnvelope(nil, c.PublicationUUID, c.Title, c.Description, c.Content, c.URL, c.LanguageCode, c.PublishedDate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewItemMessageEnvelope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, err = UpdateItemMessageEnvelope(nil, c.PublicationUUID, c.Title, c.Description, c.Content, c.URL, c.LanguageCode, c.PublishedDate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateItemMessageEnvelope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, err = NewItemsBatchMessageEnvelope(nil, []*entity.ItemCore{newTestItemCore("en"), c})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewItemsBatchMessageEnvelope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestItemEnvelopesRejectInvalidItem(t *testing.T) {
+	c := newTestItemCore("")
+	c.Title = ""
+	if _, err := NewItemMessageEnvelope(nil, c.PublicationUUID, c.Title, c.Description, c.Content, c.URL, c.LanguageCode, c.PublishedDate); err == nil {
+		t.Error("NewItemMessageEnvelope() accepted item without title")
+	}
+	if _, err := NewItemsBatchMessageEnvelope(nil, []*entity.ItemCore{c}); err == nil {
+		t.Error("NewItemsBatchMessageEnvelope() accepted item without title")
+	}
+}
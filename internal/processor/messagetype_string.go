@@ -9,11 +9,15 @@ func _() {
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
 	_ = x[NewItemType-0]
+	_ = x[UpdateItemType-1]
+	_ = x[DisableItemType-2]
+	_ = x[EnableItemType-3]
+	_ = x[NewItemsBatchType-4]
 }
 
-const _MessageType_name = "NewItemType"
+const _MessageType_name = "NewItemTypeUpdateItemTypeDisableItemTypeEnableItemTypeNewItemsBatchType"
 
-var _MessageType_index = [...]uint8{0, 11}
+var _MessageType_index = [...]uint8{0, 11, 25, 40, 54, 71}
 
 func (i MessageType) String() string {
 	if i >= MessageType(len(_MessageType_index)-1) {
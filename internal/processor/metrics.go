@@ -0,0 +1,65 @@
+package processor
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Outcomes of single item messages, reported in metrics together with batch item outcomes
+const (
+	// OutcomeUpdated is the outcome of item updated or added by update message
+	OutcomeUpdated ItemOutcome = "updated"
+	// OutcomeStateChanged is the outcome of item moved to requested state
+	OutcomeStateChanged ItemOutcome = "state_changed"
+	// OutcomeFailed is the outcome of item, which processing failed and may be retried
+	OutcomeFailed ItemOutcome = "failed"
+)
+
+// unknownMessageType is metrics label of message, which type couldn't be read or is not supported
+const unknownMessageType = "unknown"
+
+var (
+	itemsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "naca_items",
+		Subsystem: "processor",
+		Name:      "items_total",
+		Help:      "Number of processed items by message type, outcome and publication. Publication is empty when it is not known from message.",
+	}, []string{"type", "outcome", "publication_uuid"})
+	processingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "naca_items",
+		Subsystem: "processor",
+		Name:      "message_processing_duration_seconds",
+		Help:      "Duration of message processing by message type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type"})
+)
+
+// messageTypeLabel returns metrics label for message type, limiting its values to known types
+func messageTypeLabel(messageType MessageType) string {
+	if messageType > NewItemsBatchType {
+		return unknownMessageType
+	}
+	return messageType.String()
+}
+
+// recordItem counts processed item
+func recordItem(messageType MessageType, outcome ItemOutcome, publicationUUID uuid.UUID) {
+	publication := ""
+	if publicationUUID != uuid.Nil {
+		publication = publicationUUID.String()
+	}
+	itemsProcessed.WithLabelValues(messageTypeLabel(messageType), string(outcome), publication).Inc()
+}
+
+// recordMalformedMessage counts message, which envelope couldn't be read
+func recordMalformedMessage() {
+	itemsProcessed.WithLabelValues(unknownMessageType, string(OutcomeInvalid), "").Inc()
+}
+
+// observeDuration records message processing duration since start
+func observeDuration(messageType MessageType, start time.Time) {
+	processingDuration.WithLabelValues(messageTypeLabel(messageType)).Observe(time.Since(start).Seconds())
+}
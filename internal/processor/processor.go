@@ -3,9 +3,12 @@ package processor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	otLog "github.com/opentracing/opentracing-go/log"
@@ -14,7 +17,10 @@ import (
 // ItemsRepository defines repository methods
 type ItemsRepository interface {
 	Create(context.Context, *entity.Item) error
-	ItemExists(context.Context, *entity.Item) (bool, error)
+	CreateItems(context.Context, []*entity.Item) ([]uuid.UUID, error)
+	Update(context.Context, *entity.Item) error
+	ChangeItemState(context.Context, *entity.ItemStateTransition) (*entity.ItemStateTransition, error)
+	LinkStoryCluster(context.Context, *entity.Item) (int64, error)
 }
 
 // processor is container for business logic
@@ -22,14 +28,20 @@ type processor struct {
 	repository ItemsRepository
 	logger     Logger
 	tracer     opentracing.Tracer
+	identities *entity.IdentityStrategies
+	stages     []Stage
 }
 
-// New creates processor for messaging feeds operations
-func New(repository ItemsRepository, logger Logger, tracer opentracing.Tracer) *processor {
+// New creates processor for messaging feeds operations.
+// New and updated items are identified by publication identity strategy, title and date are used if identities is nil.
+// Items are passed through stages in order before they are stored.
+func New(repository ItemsRepository, logger Logger, tracer opentracing.Tracer, identities *entity.IdentityStrategies, stages ...Stage) *processor {
 	return &processor{
 		repository,
 		logger,
 		tracer,
+		identities,
+		stages,
 	}
 }
 
@@ -39,8 +51,10 @@ func (p *processor) Process(data []byte) error {
 	var msg json.RawMessage
 	message := MessageEnvelope{Msg: &msg}
 	if err := json.Unmarshal(data, &message); err != nil {
-		return err
+		recordMalformedMessage()
+		return NewError(ErrMalformedMessage, err)
 	}
+	defer observeDuration(message.Type, time.Now())
 	// Setup tracing span
 	messageSpanContext, err := p.tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier(message.Metadata))
 	if err != nil {
@@ -56,55 +70,197 @@ func (p *processor) Process(data []byte) error {
 		var msgBody NewItemBody
 		if err := json.Unmarshal(msg, &msgBody); err != nil {
 			p.logger.Error("Failure unmarshalling body content: ", err)
-			return err
+			recordItem(message.Type, OutcomeInvalid, uuid.Nil)
+			return NewError(ErrMalformedMessage, err)
+		}
+		return p.ProcessNewItem(ctx, msgBody.ItemCore)
+	case NewItemsBatchType:
+		var msgBody NewItemsBatchBody
+		if err := json.Unmarshal(msg, &msgBody); err != nil {
+			p.logger.Error("Failure unmarshalling body content: ", err)
+			recordItem(message.Type, OutcomeInvalid, uuid.Nil)
+			return NewError(ErrMalformedMessage, err)
 		}
-		if err := msgBody.ItemCore.Validate(); err != nil {
+		results, err := p.ProcessNewItemsBatch(ctx, msgBody.Items)
+		if err != nil {
 			return err
 		}
-		return p.ProcessNewItem(ctx, msgBody.ItemCore)
+		p.logBatchResults(results)
+		return nil
+	case UpdateItemType:
+		var msgBody UpdateItemBody
+		if err := json.Unmarshal(msg, &msgBody); err != nil {
+			p.logger.Error("Failure unmarshalling body content: ", err)
+			recordItem(message.Type, OutcomeInvalid, uuid.Nil)
+			return NewError(ErrMalformedMessage, err)
+		}
+		return p.ProcessUpdateItem(ctx, msgBody.ItemCore)
+	case DisableItemType, EnableItemType:
+		var msgBody ItemStateBody
+		if err := json.Unmarshal(msg, &msgBody); err != nil {
+			p.logger.Error("Failure unmarshalling body content: ", err)
+			recordItem(message.Type, OutcomeInvalid, uuid.Nil)
+			return NewError(ErrMalformedMessage, err)
+		}
+		state := entity.ItemStateValid
+		if message.Type == DisableItemType {
+			state = entity.ItemStateDisabled
+		}
+		transition := entity.NewItemStateTransition(msgBody.UUID, state, msgBody.Reason, msgBody.Actor)
+		if err := transition.Validate(); err != nil {
+			recordItem(message.Type, OutcomeInvalid, uuid.Nil)
+			return NewValidationError(err)
+		}
+		return p.ChangeItemState(ctx, transition)
 	default:
 		p.logger.Error("Undefined message type: ", message.Type)
-		// TODO: implement common errors
-		return fmt.Errorf("Undefined message type: %v", message.Type)
+		recordItem(message.Type, OutcomeInvalid, uuid.Nil)
+		return NewError(ErrUnknownMessageType, fmt.Errorf("undefined message type %v", message.Type))
 	}
 }
 
+// ProcessNewItem passes new item through processing stages, validates and adds it.
+// Item UUID is derived from received fields by publication identity strategy, so stages don't change item identity.
 func (p *processor) ProcessNewItem(ctx context.Context, itemCore *entity.ItemCore) error {
-	item := entity.NewFilledItem(itemCore)
-	//TODO: next process steps are here
+	if itemCore == nil {
+		recordItem(NewItemType, OutcomeInvalid, uuid.Nil)
+		return NewError(ErrMalformedMessage, errors.New("item is empty"))
+	}
+	item := p.identities.NewItem(itemCore)
+	if err := PrepareItem(ctx, item, p.stages...); err != nil {
+		recordItem(NewItemType, OutcomeInvalid, item.PublicationUUID)
+		return err
+	}
 	return p.CreateItem(ctx, item)
 }
 
-// CreateItem adds it to the system
+// CreateItem adds it to the system, already existing item is not an error
 func (p *processor) CreateItem(ctx context.Context, item *entity.Item) error {
 	span, ctx := p.setupTracingSpan(ctx, "create-new-item")
 	defer span.Finish()
 	span.SetTag("item.UUID", item.UUID)
 	span.SetTag("item.publicationUUID", item.PublicationUUID)
-	itemExist, err := p.repository.ItemExists(ctx, item)
+	err := p.repository.Create(ctx, item)
+	if errors.Is(err, entity.ErrItemExists) {
+		// Redelivered or concurrently processed copy of message, or another item with the same canonical URL, item is already there
+		p.logger.Info("Item ", item.UUID, " already exists, skipping")
+		span.LogKV("event", "item exists")
+		recordItem(NewItemType, OutcomeDuplicate, item.PublicationUUID)
+		return nil
+	}
+	if err != nil {
+		span.LogFields(
+			otLog.Error(err),
+		)
+		recordItem(NewItemType, OutcomeFailed, item.PublicationUUID)
+		return NewError(ErrRepositoryUnavailable, err)
+	}
+	recordItem(NewItemType, OutcomeCreated, item.PublicationUUID)
+	p.logger.Info("Processed new item ", item.UUID, ", publication ", item.PublicationUUID)
+	span.LogKV("event", "created item")
+	if item.Fingerprint != 0 {
+		p.linkStoryCluster(ctx, item)
+	}
+	return nil
+}
+
+// linkStoryCluster links stored item with its near-duplicates, item without them leaves its cluster.
+// Item is already stored, so failure is only logged and item stays in its previous cluster or out of cluster.
+func (p *processor) linkStoryCluster(ctx context.Context, item *entity.Item) {
+	span, ctx := p.setupTracingSpan(ctx, "link-story-cluster")
+	defer span.Finish()
+	span.SetTag("item.UUID", item.UUID)
+	clusterID, err := p.repository.LinkStoryCluster(ctx, item)
 	if err != nil {
-		checkErrMsg := fmt.Errorf("couldn't check if item with UUID %s exists in repository", item.UUID)
 		span.LogFields(
-			otLog.Error(checkErrMsg),
+			otLog.Error(err),
 		)
-		return checkErrMsg
+		p.logger.Warn("Failure linking item ", item.UUID, " to story cluster: ", err)
+		return
+	}
+	if clusterID != 0 {
+		p.logger.Debug("Linked item ", item.UUID, " to story cluster ", clusterID)
+		span.LogKV("event", "linked story cluster", "cluster", clusterID)
+	}
+}
+
+// ProcessUpdateItem passes updated item through the same processing stages as new item, validates and stores it
+func (p *processor) ProcessUpdateItem(ctx context.Context, itemCore *entity.ItemCore) error {
+	if itemCore == nil {
+		recordItem(UpdateItemType, OutcomeInvalid, uuid.Nil)
+		return NewError(ErrMalformedMessage, errors.New("item is empty"))
+	}
+	item := p.identities.NewItem(itemCore)
+	if err := PrepareItem(ctx, item, p.stages...); err != nil {
+		recordItem(UpdateItemType, OutcomeInvalid, item.PublicationUUID)
+		return err
+	}
+	return p.UpdateItem(ctx, item)
+}
+
+// UpdateItem updates existing item in the system or adds it, if it is missing.
+// Item, which canonical URL belongs to another item of publication, is skipped.
+func (p *processor) UpdateItem(ctx context.Context, item *entity.Item) error {
+	span, ctx := p.setupTracingSpan(ctx, "update-item")
+	defer span.Finish()
+	span.SetTag("item.UUID", item.UUID)
+	span.SetTag("item.publicationUUID", item.PublicationUUID)
+	err := p.repository.Update(ctx, item)
+	if errors.Is(err, entity.ErrItemExists) {
+		p.logger.Info("Item with canonical URL ", item.CanonicalURL, " of item ", item.UUID, " already exists, skipping")
+		span.LogKV("event", "item exists")
+		recordItem(UpdateItemType, OutcomeDuplicate, item.PublicationUUID)
+		return nil
 	}
-	if itemExist {
-		existsErrMsg := fmt.Errorf("item %s already exist in repository", item.UUID)
+	if err != nil {
 		span.LogFields(
-			otLog.Error(existsErrMsg),
+			otLog.Error(err),
 		)
-		p.logger.Error(existsErrMsg)
-		return existsErrMsg
+		recordItem(UpdateItemType, OutcomeFailed, item.PublicationUUID)
+		return NewError(ErrRepositoryUnavailable, err)
+	}
+	recordItem(UpdateItemType, OutcomeUpdated, item.PublicationUUID)
+	p.logger.Info("Processed updated item ", item.UUID, ", publication ", item.PublicationUUID)
+	span.LogKV("event", "updated item")
+	// Updated text changes fingerprint, missing item is created by update, so item is relinked
+	p.linkStoryCluster(ctx, item)
+	return nil
+}
+
+// ChangeItemState moves item to the requested state, recording the transition
+func (p *processor) ChangeItemState(ctx context.Context, transition *entity.ItemStateTransition) error {
+	span, ctx := p.setupTracingSpan(ctx, "change-item-state")
+	defer span.Finish()
+	span.SetTag("item.UUID", transition.ItemUUID)
+	span.SetTag("item.state", transition.ToState)
+	messageType := EnableItemType
+	if transition.ToState == entity.ItemStateDisabled {
+		messageType = DisableItemType
 	}
-	if err := p.repository.Create(ctx, item); err != nil {
+	recorded, err := p.repository.ChangeItemState(ctx, transition)
+	if err != nil {
 		span.LogFields(
 			otLog.Error(err),
 		)
-		return err
+		recordItem(messageType, OutcomeFailed, uuid.Nil)
+		return NewError(ErrRepositoryUnavailable, err)
 	}
-	p.logger.Info("Processed new item ", item.UUID, ", publication ", item.PublicationUUID)
-	span.LogKV("event", "created item")
+	if recorded == nil {
+		notFoundErr := NewError(ErrItemNotFound, fmt.Errorf("item %s doesn't exist in repository", transition.ItemUUID))
+		span.LogFields(
+			otLog.Error(notFoundErr),
+		)
+		recordItem(messageType, OutcomeFailed, uuid.Nil)
+		return notFoundErr
+	}
+	if !recorded.Changed() {
+		p.logger.Info("Item ", transition.ItemUUID, " is already in state ", transition.ToState)
+		recordItem(messageType, OutcomeDuplicate, uuid.Nil)
+		return nil
+	}
+	recordItem(messageType, OutcomeStateChanged, uuid.Nil)
+	p.logger.Info("Changed item ", recorded.ItemUUID, " state from ", recorded.FromState, " to ", recorded.ToState, " by ", recorded.Actor, ": ", recorded.Reason)
+	span.LogKV("event", "changed item state")
 	return nil
 }
 
@@ -0,0 +1,109 @@
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/internal/processor"
+	"github.com/Tarick/naca-items/internal/processor/stages"
+	"github.com/Tarick/naca-items/internal/repository/memory"
+	"github.com/gofrs/uuid"
+	"github.com/opentracing/opentracing-go"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(args ...interface{}) {}
+func (nopLogger) Info(args ...interface{})  {}
+func (nopLogger) Warn(args ...interface{})  {}
+func (nopLogger) Error(args ...interface{}) {}
+func (nopLogger) Fatal(args ...interface{}) {}
+
+const (
+	storyText = "The city council approved the new budget on Tuesday evening after a long debate about public transport, " +
+		"schools and the maintenance of roads and bridges across the region."
+	unrelatedText = "The national football team won the championship final on Sunday night after a dramatic penalty shootout, " +
+		"and thousands of supporters celebrated in the streets of the capital until the early morning."
+)
+
+func newStoryItemCore(publicationUUID uuid.UUID, title string, content string) *entity.ItemCore {
+	itemCore := entity.NewItemCore()
+	itemCore.PublicationUUID = publicationUUID
+	itemCore.PublishedDate = time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	itemCore.Title = title
+	itemCore.Content = content
+	itemCore.LanguageCode = "en"
+	return itemCore
+}
+
+func TestProcessUpdateItemLinksStoryCluster(t *testing.T) {
+	ctx := context.Background()
+	repository := memory.New()
+	fingerprint := stages.NewFingerprint(stages.FingerprintConfig{Enabled: true, MinWords: 10})
+	p := processor.New(repository, nopLogger{}, opentracing.NoopTracer{}, nil, fingerprint)
+
+	original := newStoryItemCore(uuid.Must(uuid.NewV4()), "Budget approved", storyText)
+	if err := p.ProcessNewItem(ctx, original); err != nil {
+		t.Fatalf("ProcessNewItem() failed: %v", err)
+	}
+	// Missing item is created by update and linked with near-duplicate
+	syndicated := newStoryItemCore(uuid.Must(uuid.NewV4()), "Budget approved", storyText+" Reporting by agency.")
+	if err := p.ProcessUpdateItem(ctx, syndicated); err != nil {
+		t.Fatalf("ProcessUpdateItem() failed: %v", err)
+	}
+	syndicatedUUID := entity.NewFilledItem(syndicated).UUID
+	item, err := repository.GetItemByUUID(ctx, syndicatedUUID)
+	if err != nil || item == nil {
+		t.Fatalf("GetItemByUUID() = %v, %v", item, err)
+	}
+	if item.ClusterID == 0 {
+		t.Fatal("item created by update is not linked to story cluster")
+	}
+	clusterID := item.ClusterID
+
+	// Item with changed text leaves cluster
+	changed := newStoryItemCore(syndicated.PublicationUUID, syndicated.Title, unrelatedText)
+	if err := p.ProcessUpdateItem(ctx, changed); err != nil {
+		t.Fatalf("ProcessUpdateItem() failed: %v", err)
+	}
+	if item, err = repository.GetItemByUUID(ctx, syndicatedUUID); err != nil || item == nil {
+		t.Fatalf("GetItemByUUID() = %v, %v", item, err)
+	}
+	if item.ClusterID != 0 {
+		t.Errorf("updated item with unrelated text stays in cluster %d", item.ClusterID)
+	}
+	clusterItems, err := repository.GetItemsByClusterID(ctx, clusterID, 10)
+	if err != nil {
+		t.Fatalf("GetItemsByClusterID() failed: %v", err)
+	}
+	if len(clusterItems) != 1 {
+		t.Errorf("cluster has %d items, want only the original", len(clusterItems))
+	}
+}
+
+func TestProcessNewItemsBatchClassifiesDuplicates(t *testing.T) {
+	ctx := context.Background()
+	p := processor.New(memory.New(), nopLogger{}, opentracing.NoopTracer{}, nil)
+	publicationUUID := uuid.Must(uuid.NewV4())
+	stored := newStoryItemCore(publicationUUID, "Budget approved", storyText)
+	if err := p.ProcessNewItem(ctx, stored); err != nil {
+		t.Fatalf("ProcessNewItem() failed: %v", err)
+	}
+	added := newStoryItemCore(publicationUUID, "Championship won", unrelatedText)
+	results, err := p.ProcessNewItemsBatch(ctx, []*entity.ItemCore{stored, added, added})
+	if err != nil {
+		t.Fatalf("ProcessNewItemsBatch() failed: %v", err)
+	}
+	wantOutcomes := []processor.ItemOutcome{processor.OutcomeDuplicate, processor.OutcomeCreated, processor.OutcomeDuplicate}
+	for i, result := range results {
+		if result.Outcome != wantOutcomes[i] {
+			t.Errorf("item %d outcome = %s, want %s", i, result.Outcome, wantOutcomes[i])
+		}
+		if isDuplicate := errors.Is(result.Err, processor.ErrDuplicateItem); isDuplicate != (wantOutcomes[i] == processor.OutcomeDuplicate) {
+			t.Errorf("item %d error = %v, want duplicate error only for duplicate item", i, result.Err)
+		}
+	}
+}
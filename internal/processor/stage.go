@@ -0,0 +1,59 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otLog "github.com/opentracing/opentracing-go/log"
+)
+
+// Stage is a step of item processing, which prepares item fields before item is validated and stored.
+// Stages are run in order, item UUID is already set and must not be changed by stage.
+type Stage interface {
+	// Name is used in logs and tracing
+	Name() string
+	Process(ctx context.Context, item *entity.Item) error
+}
+
+// PrepareItem runs processing stages and validates their result, content stages couldn't process is invalid too.
+// Every source of items, e.g. GraphQL API, prepares them the same way processor does, so stored items have the same form.
+// Errors are validation errors, see NewValidationError.
+func PrepareItem(ctx context.Context, item *entity.Item, stages ...Stage) error {
+	if err := runStages(ctx, item, stages); err != nil {
+		return NewValidationError(err)
+	}
+	if err := item.ItemCore.Validate(); err != nil {
+		return NewValidationError(err)
+	}
+	return nil
+}
+
+// runStages passes item through processing stages, stage failure makes item invalid.
+// Span is started with tracer of ctx span, if there is one.
+func runStages(ctx context.Context, item *entity.Item, stages []Stage) error {
+	if len(stages) == 0 {
+		return nil
+	}
+	tracer := opentracing.GlobalTracer()
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		tracer = parent.Tracer()
+	}
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "run-item-stages")
+	ext.Component.Set(span, "ItemsProcessor")
+	defer span.Finish()
+	span.SetTag("item.UUID", item.UUID)
+	for _, stage := range stages {
+		if err := stage.Process(ctx, item); err != nil {
+			err = fmt.Errorf("stage %s failed: %w", stage.Name(), err)
+			span.LogFields(
+				otLog.Error(err),
+			)
+			return err
+		}
+		span.LogKV("event", "processed by stage", "stage", stage.Name())
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+// stageFunc is a test stage
+type stageFunc func(item *entity.Item) error
+
+func (f stageFunc) Name() string {
+	return "test"
+}
+
+func (f stageFunc) Process(ctx context.Context, item *entity.Item) error {
+	return f(item)
+}
+
+func TestPrepareItem(t *testing.T) {
+	fillLanguage := stageFunc(func(item *entity.Item) error {
+		item.LanguageCode = "en"
+		return nil
+	})
+	failure := stageFunc(func(item *entity.Item) error {
+		return errors.New("broken markup")
+	})
+	tests := []struct {
+		name         string
+		languageCode string
+		stages       []Stage
+		wantErr      bool
+	}{
+		{name: "valid item without stages", languageCode: "en"},
+		{name: "language filled by stage", languageCode: "", stages: []Stage{fillLanguage}},
+		{name: "invalid prepared item", languageCode: "", wantErr: true},
+		{name: "stage failure", languageCode: "en", stages: []Stage{failure, fillLanguage}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := entity.NewFilledItem(newTestItemCore(tt.languageCode))
+			err := PrepareItem(context.Background(), item, tt.stages...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PrepareItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrValidation) {
+				t.Errorf("PrepareItem() error = %v, want validation error", err)
+			}
+		})
+	}
+}
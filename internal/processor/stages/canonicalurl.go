@@ -0,0 +1,42 @@
+package stages
+
+import (
+	"context"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+// CanonicalizeURLConfig defines URL canonicalization stage configuration.
+// Rules are shared with canonical_url identity strategy and configured separately.
+type CanonicalizeURLConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// CanonicalizeURL sets item CanonicalURL, original URL is kept.
+// URL, which couldn't be canonicalized (e.g. relative one), leaves canonical URL empty.
+type CanonicalizeURL struct {
+	canonicalizer *entity.URLCanonicalizer
+}
+
+// NewCanonicalizeURL creates URL canonicalization stage, default rules are used if canonicalizer is nil
+func NewCanonicalizeURL(canonicalizer *entity.URLCanonicalizer) *CanonicalizeURL {
+	if canonicalizer == nil {
+		canonicalizer = entity.NewURLCanonicalizer()
+	}
+	return &CanonicalizeURL{canonicalizer: canonicalizer}
+}
+
+// Name returns stage name
+func (c *CanonicalizeURL) Name() string {
+	return "canonicalize_url"
+}
+
+// Process canonicalizes item URL
+func (c *CanonicalizeURL) Process(ctx context.Context, item *entity.Item) error {
+	canonical, err := c.canonicalizer.Canonicalize(item.URL)
+	if err != nil {
+		canonical = ""
+	}
+	item.CanonicalURL = canonical
+	return nil
+}
@@ -0,0 +1,61 @@
+package stages
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+const defaultMinFingerprintWords = 20
+
+// FingerprintConfig defines fingerprint stage configuration
+type FingerprintConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MinWords in item text to compute fingerprint, short texts like bare titles give false near-duplicates
+	MinWords int `mapstructure:"min_words"`
+}
+
+// Fingerprint sets SimHash fingerprint of item title, description and content, so near-duplicate items are linked into story clusters
+type Fingerprint struct {
+	minWords int
+}
+
+// NewFingerprint creates fingerprint stage
+func NewFingerprint(config FingerprintConfig) *Fingerprint {
+	minWords := config.MinWords
+	if minWords <= 0 {
+		minWords = defaultMinFingerprintWords
+	}
+	return &Fingerprint{minWords: minWords}
+}
+
+// Name returns stage name
+func (f *Fingerprint) Name() string {
+	return "fingerprint"
+}
+
+// Process computes item fingerprint
+func (f *Fingerprint) Process(ctx context.Context, item *entity.Item) error {
+	item.Fingerprint = 0
+	description, err := htmlToText(item.Description)
+	if err != nil {
+		return err
+	}
+	content := item.ContentText
+	if content == "" {
+		if content, err = htmlToText(item.Content); err != nil {
+			return err
+		}
+	}
+	text := strings.Join([]string{item.Title, description, content}, "\n")
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) < f.minWords {
+		return nil
+	}
+	item.Fingerprint = entity.NewFingerprint(text)
+	return nil
+}
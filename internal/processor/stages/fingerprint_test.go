@@ -0,0 +1,60 @@
+package stages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name        string
+		item        *entity.Item
+		wantTextOf  string
+		wantMissing bool
+	}{
+		{
+			name:       "title, description and content text",
+			item:       &entity.Item{ItemCore: &entity.ItemCore{Title: "Budget approved", Description: "<p>Council vote</p>", Content: "<p>" + englishText + "</p>"}},
+			wantTextOf: "Budget approved\nCouncil vote\n" + englishText,
+		},
+		{
+			name:       "content text is preferred to content",
+			item:       &entity.Item{ItemCore: &entity.ItemCore{Title: "Budget approved", Content: "<p>ignored</p>"}, ContentText: englishText},
+			wantTextOf: "Budget approved\n\n" + englishText,
+		},
+		{
+			name:        "short text has no fingerprint",
+			item:        &entity.Item{ItemCore: &entity.ItemCore{Title: "Budget approved", Content: "<p>Council vote on Tuesday</p>"}, Fingerprint: 42},
+			wantMissing: true,
+		},
+	}
+	stage := NewFingerprint(FingerprintConfig{Enabled: true})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := stage.Process(context.Background(), tt.item); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if tt.wantMissing {
+				if tt.item.Fingerprint != 0 {
+					t.Errorf("Fingerprint = %x, want 0", tt.item.Fingerprint)
+				}
+				return
+			}
+			if want := entity.NewFingerprint(tt.wantTextOf); tt.item.Fingerprint != want {
+				t.Errorf("Fingerprint = %x, want %x", tt.item.Fingerprint, want)
+			}
+		})
+	}
+}
+
+func TestFingerprintMinWords(t *testing.T) {
+	item := &entity.Item{ItemCore: &entity.ItemCore{Title: "Budget approved by city council"}}
+	if err := NewFingerprint(FingerprintConfig{Enabled: true, MinWords: 5}).Process(context.Background(), item); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if item.Fingerprint == 0 {
+		t.Error("Fingerprint is not set for text with min words")
+	}
+}
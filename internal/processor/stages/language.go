@@ -0,0 +1,146 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/abadojack/whatlanggo"
+	"github.com/asaskevich/govalidator"
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	defaultMinConfidence     = 0.9
+	defaultCorrectConfidence = 0.99
+	defaultMinTextLength     = 40
+	// maxDetectionTextLength limits detection text, beginning of item is enough to detect language
+	maxDetectionTextLength = 2000
+)
+
+// DetectLanguageConfig defines language detection stage configuration
+type DetectLanguageConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MinConfidence (0-1) of detection to fill missing or invalid language code
+	MinConfidence float64 `mapstructure:"min_confidence"`
+	// CorrectConfidence (0-1) of detection to replace declared language code
+	CorrectConfidence float64 `mapstructure:"correct_confidence"`
+	// MinTextLength in characters, shorter texts are not detected reliably
+	MinTextLength int `mapstructure:"min_text_length"`
+	// Languages limits detection to ISO 639-1 codes, all supported languages are used by default
+	Languages []string `mapstructure:"languages"`
+}
+
+// DetectLanguage detects item language with trigram statistics over title, description and content.
+// Confident detection fills missing language code or corrects the declared one, both codes are recorded in item.
+type DetectLanguage struct {
+	minConfidence     float64
+	correctConfidence float64
+	minTextLength     int
+	options           whatlanggo.Options
+}
+
+// NewDetectLanguage creates language detection stage
+func NewDetectLanguage(config DetectLanguageConfig) (*DetectLanguage, error) {
+	stage := &DetectLanguage{
+		minConfidence:     config.MinConfidence,
+		correctConfidence: config.CorrectConfidence,
+		minTextLength:     config.MinTextLength,
+	}
+	if stage.minConfidence <= 0 {
+		stage.minConfidence = defaultMinConfidence
+	}
+	if stage.correctConfidence <= 0 {
+		stage.correctConfidence = defaultCorrectConfidence
+	}
+	if stage.minTextLength <= 0 {
+		stage.minTextLength = defaultMinTextLength
+	}
+	if len(config.Languages) > 0 {
+		stage.options.Whitelist = map[whatlanggo.Lang]bool{}
+		for _, code := range config.Languages {
+			lang, ok := langByCode(strings.ToLower(code))
+			if !ok {
+				return nil, fmt.Errorf("language %q is not supported by detection", code)
+			}
+			stage.options.Whitelist[lang] = true
+		}
+	}
+	return stage, nil
+}
+
+// Name returns stage name
+func (d *DetectLanguage) Name() string {
+	return "detect_language"
+}
+
+// Process detects item language
+func (d *DetectLanguage) Process(ctx context.Context, item *entity.Item) error {
+	declared := strings.ToLower(strings.TrimSpace(item.LanguageCode))
+	item.DeclaredLanguageCode = declared
+	if !govalidator.IsISO693Alpha2(declared) {
+		declared = ""
+	}
+	item.LanguageCode = declared
+
+	detected, confidence := d.detect(item)
+	span := opentracing.SpanFromContext(ctx)
+	if span != nil {
+		span.LogKV("event", "detected language", "declared", item.DeclaredLanguageCode, "detected", detected, "confidence", confidence)
+	}
+	if confidence < d.minConfidence {
+		return nil
+	}
+	item.DetectedLanguageCode = detected
+	if declared == "" || (declared != detected && confidence >= d.correctConfidence) {
+		item.LanguageCode = detected
+	}
+	return nil
+}
+
+// detect returns ISO 639-1 code of item language and detection confidence, which is 0 if language couldn't be detected
+func (d *DetectLanguage) detect(item *entity.Item) (string, float64) {
+	text, err := detectionText(item)
+	if err != nil || len([]rune(text)) < d.minTextLength {
+		return "", 0
+	}
+	info := whatlanggo.DetectWithOptions(text, d.options)
+	code := ""
+	if info.Lang >= 0 {
+		code = info.Lang.Iso6391()
+	}
+	if code == "" {
+		return "", 0
+	}
+	return code, info.Confidence
+}
+
+// detectionText joins plain text of title, description and content
+func detectionText(item *entity.Item) (string, error) {
+	description, err := htmlToText(item.Description)
+	if err != nil {
+		return "", err
+	}
+	content := item.ContentText
+	if content == "" {
+		if content, err = htmlToText(item.Content); err != nil {
+			return "", err
+		}
+	}
+	text := []rune(strings.Join([]string{item.Title, description, content}, "\n"))
+	if len(text) > maxDetectionTextLength {
+		text = text[:maxDetectionTextLength]
+	}
+	return string(text), nil
+}
+
+// langByCode finds detection language by ISO 639-1 code
+func langByCode(code string) (whatlanggo.Lang, bool) {
+	for lang := range whatlanggo.Langs {
+		if lang.Iso6391() == code {
+			return lang, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package stages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+const (
+	englishText = "The city council approved the new budget on Tuesday evening after a long debate about public transport, " +
+		"schools and the maintenance of roads and bridges across the region."
+	germanText = "Der Stadtrat hat am Dienstagabend nach einer langen Debatte über den öffentlichen Verkehr, " +
+		"die Schulen und die Instandhaltung der Straßen und Brücken in der Region den neuen Haushalt beschlossen."
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name         string
+		languageCode string
+		content      string
+		want         string
+		wantDeclared string
+		wantDetected string
+	}{
+		{name: "missing language filled", languageCode: "", content: englishText, want: "en", wantDeclared: "", wantDetected: "en"},
+		{name: "invalid language filled", languageCode: "xx", content: germanText, want: "de", wantDeclared: "xx", wantDetected: "de"},
+		{name: "declared language normalized", languageCode: " EN ", content: englishText, want: "en", wantDeclared: "en", wantDetected: "en"},
+		{name: "wrong language corrected", languageCode: "fr", content: germanText, want: "de", wantDeclared: "fr", wantDetected: "de"},
+		{name: "declared language kept for short text", languageCode: "fr", content: "Short text", want: "fr", wantDeclared: "fr", wantDetected: ""},
+		{name: "missing language kept for short text", languageCode: "", content: "Short text", want: "", wantDeclared: "", wantDetected: ""},
+	}
+	stage, err := NewDetectLanguage(DetectLanguageConfig{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{ItemCore: &entity.ItemCore{Title: "News", Content: "<p>" + tt.content + "</p>", LanguageCode: tt.languageCode}}
+			if err := stage.Process(context.Background(), item); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if item.LanguageCode != tt.want {
+				t.Errorf("LanguageCode = %q, want %q", item.LanguageCode, tt.want)
+			}
+			if item.DeclaredLanguageCode != tt.wantDeclared {
+				t.Errorf("DeclaredLanguageCode = %q, want %q", item.DeclaredLanguageCode, tt.wantDeclared)
+			}
+			if item.DetectedLanguageCode != tt.wantDetected {
+				t.Errorf("DetectedLanguageCode = %q, want %q", item.DetectedLanguageCode, tt.wantDetected)
+			}
+		})
+	}
+}
+
+func TestDetectLanguageKeepsDeclaredBelowCorrectConfidence(t *testing.T) {
+	// Correction requires confidence above any possible one, so declared language is kept
+	stage, err := NewDetectLanguage(DetectLanguageConfig{Enabled: true, CorrectConfidence: 1.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := &entity.Item{ItemCore: &entity.ItemCore{Title: "News", Content: germanText, LanguageCode: "fr"}}
+	if err := stage.Process(context.Background(), item); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if item.LanguageCode != "fr" {
+		t.Errorf("LanguageCode = %q, want declared %q", item.LanguageCode, "fr")
+	}
+	if item.DetectedLanguageCode != "de" {
+		t.Errorf("DetectedLanguageCode = %q, want %q", item.DetectedLanguageCode, "de")
+	}
+}
+
+func TestNewDetectLanguageUnsupportedLanguage(t *testing.T) {
+	if _, err := NewDetectLanguage(DetectLanguageConfig{Languages: []string{"en", "zz"}}); err == nil {
+		t.Error("NewDetectLanguage() accepted unsupported language")
+	}
+}
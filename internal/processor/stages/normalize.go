@@ -0,0 +1,109 @@
+package stages
+
+import (
+	"context"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"golang.org/x/net/html"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeConfig defines normalization stage configuration
+type NormalizeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// Normalize brings item text to the canonical form: Unicode NFC, decoded entities and single spaces.
+// Title is treated as plain text, description and content as HTML, where whitespace of preformatted text is kept.
+type Normalize struct{}
+
+// NewNormalize creates normalization stage
+func NewNormalize() *Normalize {
+	return &Normalize{}
+}
+
+// Name returns stage name
+func (n *Normalize) Name() string {
+	return "normalize"
+}
+
+// Process normalizes item title, description and content
+func (n *Normalize) Process(ctx context.Context, item *entity.Item) error {
+	item.Title = normalizeText(html.UnescapeString(item.Title))
+	var err error
+	if item.Description, err = normalizeHTML(item.Description); err != nil {
+		return err
+	}
+	item.Content, err = normalizeHTML(item.Content)
+	return err
+}
+
+// normalizeText collapses whitespace, including non-breaking spaces, to single spaces
+func normalizeText(text string) string {
+	return strings.TrimSpace(collapseSpaces(norm.NFC.String(text)))
+}
+
+func collapseSpaces(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteRune(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteRune(' ')
+	}
+	return b.String()
+}
+
+// normalizeHTML re-renders markup, so entities are decoded and only markup characters are escaped
+func normalizeHTML(text string) (string, error) {
+	if text == "" {
+		return text, nil
+	}
+	tokenizer := html.NewTokenizer(strings.NewReader(norm.NFC.String(text)))
+	var (
+		b strings.Builder
+		// Depth of elements with preformatted text
+		preDepth int
+	)
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			return strings.TrimSpace(b.String()), nil
+		}
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken:
+			if isPreformatted(token.Data) {
+				preDepth++
+			}
+		case html.EndTagToken:
+			if isPreformatted(token.Data) && preDepth > 0 {
+				preDepth--
+			}
+		case html.TextToken:
+			if preDepth == 0 {
+				token.Data = collapseSpaces(token.Data)
+			}
+		}
+		b.WriteString(token.String())
+	}
+}
+
+func isPreformatted(name string) bool {
+	return name == "pre" || name == "textarea"
+}
@@ -0,0 +1,63 @@
+package stages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "spaces collapsed and trimmed", input: "  Breaking \t news\n today ", want: "Breaking news today"},
+		{name: "non-breaking spaces", input: "Breaking  news", want: "Breaking news"},
+		{name: "entities decoded", input: "Q&amp;A &quot;live&quot;", want: `Q&A "live"`},
+		{name: "decomposed characters composed", input: "Café", want: "Café"},
+	}
+	n := NewNormalize()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{ItemCore: &entity.ItemCore{Title: tt.input}}
+			if err := n.Process(context.Background(), item); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if item.Title != tt.want {
+				t.Errorf("Title = %q, want %q", item.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "spaces collapsed", input: " <p>Breaking\n\n   news</p> ", want: "<p>Breaking news</p>"},
+		{name: "entities decoded", input: "<p>&eacute;t&eacute; &amp; caf&#233;</p>", want: "<p>été &amp; café</p>"},
+		{name: "preformatted text kept", input: "<pre>a  \n  b</pre> <p>c   d</p>", want: "<pre>a  \n  b</pre> <p>c d</p>"},
+		{name: "decomposed characters composed", input: "<p>Café</p>", want: "<p>Café</p>"},
+	}
+	n := NewNormalize()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{ItemCore: &entity.ItemCore{Description: tt.input, Content: tt.input}}
+			if err := n.Process(context.Background(), item); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if item.Description != tt.want {
+				t.Errorf("Description = %q, want %q", item.Description, tt.want)
+			}
+			if item.Content != tt.want {
+				t.Errorf("Content = %q, want %q", item.Content, tt.want)
+			}
+		})
+	}
+}
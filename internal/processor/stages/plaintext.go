@@ -0,0 +1,97 @@
+package stages
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"golang.org/x/net/html"
+)
+
+// PlainTextConfig defines plain text stage configuration
+type PlainTextConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// PlainText sets item ContentText to the text of content markup.
+// Block elements are separated by line breaks, scripts and styles are skipped.
+type PlainText struct{}
+
+// NewPlainText creates plain text stage
+func NewPlainText() *PlainText {
+	return &PlainText{}
+}
+
+// Name returns stage name
+func (p *PlainText) Name() string {
+	return "plain_text"
+}
+
+// Process extracts text of item content
+func (p *PlainText) Process(ctx context.Context, item *entity.Item) error {
+	text, err := htmlToText(item.Content)
+	if err != nil {
+		return err
+	}
+	item.ContentText = text
+	return nil
+}
+
+func htmlToText(text string) (string, error) {
+	if text == "" {
+		return text, nil
+	}
+	tokenizer := html.NewTokenizer(strings.NewReader(text))
+	var (
+		b       strings.Builder
+		skipped bool
+	)
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			break
+		}
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if token.Data == "script" || token.Data == "style" {
+				skipped = tokenType == html.StartTagToken
+			}
+			if isBlockElement(token.Data) {
+				b.WriteByte('\n')
+			}
+		case html.EndTagToken:
+			if token.Data == "script" || token.Data == "style" {
+				skipped = false
+			}
+			if isBlockElement(token.Data) {
+				b.WriteByte('\n')
+			}
+		case html.TextToken:
+			if !skipped {
+				b.WriteString(token.Data)
+			}
+		}
+	}
+	// Spaces are collapsed within lines, empty lines are dropped
+	lines := []string{}
+	for _, line := range strings.Split(b.String(), "\n") {
+		if line = strings.TrimSpace(collapseSpaces(line)); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func isBlockElement(name string) bool {
+	switch name {
+	case "address", "article", "aside", "blockquote", "br", "dd", "div", "dl", "dt", "figcaption", "figure", "footer",
+		"h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "main", "nav", "ol", "p", "pre", "section", "table", "tr", "ul":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package stages
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"golang.org/x/net/html"
+)
+
+// defaultRemovedElements are dropped together with their content
+var defaultRemovedElements = []string{
+	"script", "style", "iframe", "frame", "frameset", "object", "embed", "applet",
+	"form", "input", "button", "textarea", "select", "link", "meta", "base", "noscript",
+}
+
+// urlAttributes could execute code with unsafe URL scheme
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "action": true, "formaction": true, "background": true, "poster": true, "xlink:href": true,
+}
+
+var unsafeURLSchemes = []string{"javascript:", "vbscript:", "data:"}
+
+// SanitizeHTMLConfig defines HTML sanitization stage configuration
+type SanitizeHTMLConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// RemoveElements replaces default list of elements, removed with their content
+	RemoveElements []string `mapstructure:"remove_elements"`
+}
+
+// SanitizeHTML removes unsafe elements, event handler attributes and script URLs from item description and content.
+// Comments are removed too, the rest of markup is kept.
+type SanitizeHTML struct {
+	removed map[string]bool
+}
+
+// NewSanitizeHTML creates HTML sanitization stage
+func NewSanitizeHTML(config SanitizeHTMLConfig) *SanitizeHTML {
+	elements := config.RemoveElements
+	if len(elements) == 0 {
+		elements = defaultRemovedElements
+	}
+	removed := make(map[string]bool, len(elements))
+	for _, element := range elements {
+		removed[strings.ToLower(element)] = true
+	}
+	return &SanitizeHTML{removed: removed}
+}
+
+// Name returns stage name
+func (s *SanitizeHTML) Name() string {
+	return "sanitize_html"
+}
+
+// Process sanitizes item description and content
+func (s *SanitizeHTML) Process(ctx context.Context, item *entity.Item) error {
+	var err error
+	if item.Description, err = s.sanitize(item.Description); err != nil {
+		return err
+	}
+	item.Content, err = s.sanitize(item.Content)
+	return err
+}
+
+func (s *SanitizeHTML) sanitize(text string) (string, error) {
+	if text == "" {
+		return text, nil
+	}
+	tokenizer := html.NewTokenizer(strings.NewReader(text))
+	var (
+		b strings.Builder
+		// Depth of removed elements, their content is skipped
+		removedDepth int
+	)
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			return b.String(), nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if s.removed[token.Data] {
+				if tokenType == html.StartTagToken && !isVoidElement(token.Data) {
+					removedDepth++
+				}
+				continue
+			}
+			if removedDepth > 0 {
+				continue
+			}
+			token.Attr = safeAttributes(token.Attr)
+			b.WriteString(token.String())
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if s.removed[token.Data] {
+				if removedDepth > 0 {
+					removedDepth--
+				}
+				continue
+			}
+			if removedDepth == 0 {
+				b.WriteString(token.String())
+			}
+		case html.TextToken:
+			if removedDepth == 0 {
+				b.WriteString(tokenizer.Token().String())
+			}
+		}
+		// Comments and doctype are dropped
+	}
+}
+
+// safeAttributes drops event handlers and attributes with script URLs
+func safeAttributes(attributes []html.Attribute) []html.Attribute {
+	safe := attributes[:0]
+	for _, attribute := range attributes {
+		name := strings.ToLower(attribute.Key)
+		if attribute.Namespace != "" {
+			name = strings.ToLower(attribute.Namespace) + ":" + name
+		}
+		if strings.HasPrefix(name, "on") {
+			continue
+		}
+		if urlAttributes[name] && hasUnsafeScheme(attribute.Val) {
+			continue
+		}
+		safe = append(safe, attribute)
+	}
+	return safe
+}
+
+// hasUnsafeScheme checks URL scheme, ignoring whitespace and control characters, which browsers skip too
+func hasUnsafeScheme(url string) bool {
+	url = strings.ToLower(strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, url))
+	for _, scheme := range unsafeURLSchemes {
+		if strings.HasPrefix(url, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVoidElement reports elements without end tag
+func isVoidElement(name string) bool {
+	switch name {
+	case "area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "param", "source", "track", "wbr":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package stages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "plain text", input: "Breaking news", want: "Breaking news"},
+		{name: "safe markup kept", input: `<p>Read <a href="https://example.com/a">more</a></p>`, want: `<p>Read <a href="https://example.com/a">more</a></p>`},
+		{name: "script removed with content", input: `<p>a</p><script>alert(1)</script><p>b</p>`, want: `<p>a</p><p>b</p>`},
+		{name: "nested removed elements", input: `<form><iframe src="x"></iframe><input name="q">text</form>after`, want: `after`},
+		{name: "event handler dropped", input: `<img src="a.png" onerror="alert(1)" ONLOAD="x()">`, want: `<img src="a.png">`},
+		{name: "javascript url dropped", input: `<a href="javascript:alert(1)">x</a>`, want: `<a>x</a>`},
+		{name: "obfuscated javascript url dropped", input: `<a href=" JaVa&#x09;Script:alert(1)">x</a>`, want: `<a>x</a>`},
+		{name: "data url dropped", input: `<img src="data:text/html;base64,PHNjcmlwdD4=">`, want: `<img>`},
+		{name: "comment dropped", input: `a<!-- secret -->b`, want: `ab`},
+		{name: "void removed element", input: `<meta charset="utf-8">text`, want: `text`},
+	}
+	s := NewSanitizeHTML(SanitizeHTMLConfig{Enabled: true})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{ItemCore: &entity.ItemCore{Description: tt.input, Content: tt.input}}
+			if err := s.Process(context.Background(), item); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if item.Description != tt.want {
+				t.Errorf("Description = %q, want %q", item.Description, tt.want)
+			}
+			if item.Content != tt.want {
+				t.Errorf("Content = %q, want %q", item.Content, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeHTMLRemoveElements(t *testing.T) {
+	s := NewSanitizeHTML(SanitizeHTMLConfig{Enabled: true, RemoveElements: []string{"ASIDE"}})
+	item := &entity.Item{ItemCore: &entity.ItemCore{Content: `<aside>ad</aside><script>x</script>`}}
+	if err := s.Process(context.Background(), item); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if want := `<script>x</script>`; item.Content != want {
+		t.Errorf("Content = %q, want %q", item.Content, want)
+	}
+}
@@ -0,0 +1,50 @@
+// Package stages provides built-in item processing stages, see processor.Stage
+package stages
+
+import (
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/internal/processor"
+)
+
+// Config defines processing stages configuration, usable for Viper.
+// Enabled stages are run in order: sanitize_html, normalize, plain_text, detect_language, trim_title, canonicalize_url, fingerprint.
+type Config struct {
+	SanitizeHTML    SanitizeHTMLConfig    `mapstructure:"sanitize_html"`
+	Normalize       NormalizeConfig       `mapstructure:"normalize"`
+	PlainText       PlainTextConfig       `mapstructure:"plain_text"`
+	DetectLanguage  DetectLanguageConfig  `mapstructure:"detect_language"`
+	TrimTitle       TrimTitleConfig       `mapstructure:"trim_title"`
+	CanonicalizeURL CanonicalizeURLConfig `mapstructure:"canonicalize_url"`
+	Fingerprint     FingerprintConfig     `mapstructure:"fingerprint"`
+}
+
+// New returns enabled stages in the order they must be run. URL canonicalization stage uses canonicalizer rules.
+func New(config Config, canonicalizer *entity.URLCanonicalizer) ([]processor.Stage, error) {
+	stages := []processor.Stage{}
+	if config.SanitizeHTML.Enabled {
+		stages = append(stages, NewSanitizeHTML(config.SanitizeHTML))
+	}
+	if config.Normalize.Enabled {
+		stages = append(stages, NewNormalize())
+	}
+	if config.PlainText.Enabled {
+		stages = append(stages, NewPlainText())
+	}
+	if config.DetectLanguage.Enabled {
+		detectLanguage, err := NewDetectLanguage(config.DetectLanguage)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, detectLanguage)
+	}
+	if config.TrimTitle.Enabled {
+		stages = append(stages, NewTrimTitle(config.TrimTitle))
+	}
+	if config.CanonicalizeURL.Enabled {
+		stages = append(stages, NewCanonicalizeURL(canonicalizer))
+	}
+	if config.Fingerprint.Enabled {
+		stages = append(stages, NewFingerprint(config.Fingerprint))
+	}
+	return stages, nil
+}
@@ -0,0 +1,59 @@
+package stages
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+// defaultMaxTitleLength is the item title validation limit
+const defaultMaxTitleLength = 500
+
+// TrimTitleConfig defines title trimming stage configuration
+type TrimTitleConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxLength in characters, defaults to item validation limit
+	MaxLength int `mapstructure:"max_length"`
+}
+
+// TrimTitle shortens over-long titles at word boundary and marks them with ellipsis, so such items pass validation
+type TrimTitle struct {
+	maxLength int
+}
+
+// NewTrimTitle creates title trimming stage
+func NewTrimTitle(config TrimTitleConfig) *TrimTitle {
+	maxLength := config.MaxLength
+	if maxLength <= 0 || maxLength > defaultMaxTitleLength {
+		maxLength = defaultMaxTitleLength
+	}
+	return &TrimTitle{maxLength: maxLength}
+}
+
+// Name returns stage name
+func (t *TrimTitle) Name() string {
+	return "trim_title"
+}
+
+// Process trims item title
+func (t *TrimTitle) Process(ctx context.Context, item *entity.Item) error {
+	item.Title = trimText(item.Title, t.maxLength)
+	return nil
+}
+
+func trimText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	// Leave room for ellipsis
+	trimmed := string(runes[:maxLength-1])
+	// Cut at word boundary, unless it loses most of the text
+	if cut := strings.LastIndexFunc(trimmed, unicode.IsSpace); cut > 0 && utf8.RuneCountInString(trimmed[:cut]) > maxLength/2 {
+		trimmed = trimmed[:cut]
+	}
+	return strings.TrimRightFunc(trimmed, unicode.IsSpace) + "…"
+}
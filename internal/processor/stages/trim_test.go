@@ -0,0 +1,52 @@
+package stages
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+func TestTrimTitle(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLength int
+		input     string
+		want      string
+	}{
+		{name: "short title kept", maxLength: 20, input: "Breaking news", want: "Breaking news"},
+		{name: "title of max length kept", maxLength: 13, input: "Breaking news", want: "Breaking news"},
+		{name: "cut at word boundary", maxLength: 20, input: "Breaking news from the city council", want: "Breaking news from…"},
+		{name: "long word cut", maxLength: 10, input: "Supercalifragilistic word", want: "Supercali…"},
+		{name: "word boundary losing most of text ignored", maxLength: 20, input: "A Supercalifragilisticexpialidocious", want: "A Supercalifragilis…"},
+		{name: "multibyte characters counted", maxLength: 5, input: "Привет мир", want: "Прив…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.Item{ItemCore: &entity.ItemCore{Title: tt.input}}
+			if err := NewTrimTitle(TrimTitleConfig{Enabled: true, MaxLength: tt.maxLength}).Process(context.Background(), item); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if item.Title != tt.want {
+				t.Errorf("Title = %q, want %q", item.Title, tt.want)
+			}
+			if length := utf8.RuneCountInString(item.Title); length > tt.maxLength {
+				t.Errorf("Title length = %d, want at most %d", length, tt.maxLength)
+			}
+		})
+	}
+}
+
+func TestTrimTitleDefaultMaxLength(t *testing.T) {
+	for _, maxLength := range []int{0, -1, defaultMaxTitleLength + 1} {
+		item := &entity.Item{ItemCore: &entity.ItemCore{Title: strings.Repeat("word ", defaultMaxTitleLength)}}
+		if err := NewTrimTitle(TrimTitleConfig{Enabled: true, MaxLength: maxLength}).Process(context.Background(), item); err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+		if length := utf8.RuneCountInString(item.Title); length > defaultMaxTitleLength {
+			t.Errorf("MaxLength %d: title length = %d, want at most %d", maxLength, length, defaultMaxTitleLength)
+		}
+	}
+}
@@ -0,0 +1,506 @@
+// Package memory provides in-memory items repository with the same semantics as PostgreSQL one.
+// It is intended for tests and local development, data is lost on exit.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/internal/graph/resolver"
+	"github.com/Tarick/naca-items/internal/outbox"
+	"github.com/Tarick/naca-items/internal/processor"
+	"github.com/Tarick/naca-items/pkg/itemevents"
+	"github.com/gofrs/uuid"
+)
+
+var (
+	_ processor.ItemsRepository = (*Repository)(nil)
+	_ resolver.ItemsRepository  = (*Repository)(nil)
+	_ outbox.Repository         = (*Repository)(nil)
+)
+
+// Search ranking weights of item fields, the same as PostgreSQL default weights for A, B and C labels
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+	contentWeight     = 0.2
+)
+
+// record is the stored item with its state
+type record struct {
+	item       entity.Item
+	state      entity.ItemState
+	createdAt  time.Time
+	modifiedAt time.Time
+}
+
+// Repository is in-memory items repository, safe for concurrent use
+type Repository struct {
+	mu          sync.RWMutex
+	records     map[uuid.UUID]*record
+	transitions []entity.ItemStateTransition
+	handlers    []func(*entity.ItemEvent)
+	// lastClusterID is the ID of the last created story cluster
+	lastClusterID int64
+	// outbox keeps item events till they are published, the same as PostgreSQL outbox
+	outbox      []*itemevents.EventEnvelope
+	lastEventID int64
+}
+
+// New creates empty repository
+func New() *Repository {
+	return &Repository{records: map[uuid.UUID]*record{}}
+}
+
+// Subscribe adds handler of insert events of created items, the same as database notifies about.
+// Must be called before repository is used.
+func (repository *Repository) Subscribe(handler func(*entity.ItemEvent)) {
+	repository.handlers = append(repository.handlers, handler)
+}
+
+// notifyInserted calls handlers for created item, must be called without lock held
+func (repository *Repository) notifyInserted(item *entity.Item) {
+	event := &entity.ItemEvent{
+		Operation:       entity.ItemEventInsert,
+		UUID:            item.UUID,
+		PublicationUUID: item.PublicationUUID,
+		LanguageCode:    item.LanguageCode,
+	}
+	for _, handle := range repository.handlers {
+		handle(event)
+	}
+}
+
+// copyItem returns deep copy of item, so stored items are not changed by callers
+func copyItem(item *entity.Item) *entity.Item {
+	itemCore := *item.ItemCore
+	copied := *item
+	copied.ItemCore = &itemCore
+	return &copied
+}
+
+// GetItemByUUID returns valid item found by UUID or nil
+func (repository *Repository) GetItemByUUID(ctx context.Context, UUID uuid.UUID) (*entity.Item, error) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+	r, ok := repository.records[UUID]
+	if !ok || r.state != entity.ItemStateValid {
+		return nil, nil
+	}
+	return copyItem(&r.item), nil
+}
+
+// GetItems returns all valid items
+func (repository *Repository) GetItems(ctx context.Context) ([]*entity.Item, error) {
+	return repository.filterItems(func(item *entity.Item) bool { return true }, false), nil
+}
+
+// GetItemsByPublicationUUID returns valid items filtered by PublicationUUID
+func (repository *Repository) GetItemsByPublicationUUID(ctx context.Context, publicationUUID uuid.UUID) ([]*entity.Item, error) {
+	return repository.filterItems(byPublicationUUID(publicationUUID), false), nil
+}
+
+// GetItemsByPublicationUUIDSortByPublishedDate returns valid items filtered by PublicationUUID and sorted by publishedDate
+func (repository *Repository) GetItemsByPublicationUUIDSortByPublishedDate(ctx context.Context, publicationUUID uuid.UUID, sortAsc bool) ([]*entity.Item, error) {
+	return repository.filterItems(byPublicationUUID(publicationUUID), sortAsc), nil
+}
+
+// GetItemsPageByPublicationUUID returns page of valid items filtered by PublicationUUID and sorted by publishedDate and UUID,
+// see postgresql.Repository.GetItemsPageByPublicationUUID for parameters description
+func (repository *Repository) GetItemsPageByPublicationUUID(ctx context.Context, publicationUUID uuid.UUID, sortAsc bool, after *entity.ItemCursor, before *entity.ItemCursor, limit int, fromEnd bool) ([]*entity.Item, error) {
+	filter := func(item *entity.Item) bool {
+		if item.PublicationUUID != publicationUUID {
+			return false
+		}
+		if after != nil && !cursorLess(after, entity.NewItemCursor(item), sortAsc) {
+			return false
+		}
+		if before != nil && !cursorLess(entity.NewItemCursor(item), before, sortAsc) {
+			return false
+		}
+		return true
+	}
+	items := repository.filterItems(filter, sortAsc)
+	if limit > 0 && len(items) > limit {
+		if fromEnd {
+			items = items[len(items)-limit:]
+		} else {
+			items = items[:limit]
+		}
+	}
+	return items, nil
+}
+
+// CountItemsByPublicationUUID returns number of valid items with PublicationUUID
+func (repository *Repository) CountItemsByPublicationUUID(ctx context.Context, publicationUUID uuid.UUID) (int, error) {
+	return len(repository.filterItems(byPublicationUUID(publicationUUID), false)), nil
+}
+
+// SearchItems returns valid items, containing all query words, ordered by rank.
+// Unlike PostgreSQL, words are not stemmed and search operators are not supported.
+func (repository *Repository) SearchItems(ctx context.Context, searchQuery *entity.ItemSearchQuery) ([]*entity.ItemSearchResult, error) {
+	terms := map[string]bool{}
+	for _, word := range words(searchQuery.Query) {
+		terms[word] = true
+	}
+	if len(terms) == 0 {
+		return []*entity.ItemSearchResult{}, nil
+	}
+	results := []*entity.ItemSearchResult{}
+	filter := func(item *entity.Item) bool {
+		if searchQuery.LanguageCode != "" && item.LanguageCode != searchQuery.LanguageCode {
+			return false
+		}
+		return searchQuery.PublicationUUID == uuid.Nil || item.PublicationUUID == searchQuery.PublicationUUID
+	}
+	for _, item := range repository.filterItems(filter, false) {
+		titleMatches := countMatches(item.Title, terms)
+		descriptionMatches := countMatches(item.Description, terms)
+		contentMatches := countMatches(item.Content, terms)
+		found := true
+		for term := range terms {
+			if titleMatches[term]+descriptionMatches[term]+contentMatches[term] == 0 {
+				found = false
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		results = append(results, &entity.ItemSearchResult{
+			Item:                 item,
+			Rank:                 titleWeight*sum(titleMatches) + descriptionWeight*sum(descriptionMatches) + contentWeight*sum(contentMatches),
+			TitleHighlight:       highlight(item.Title, terms),
+			DescriptionHighlight: highlight(item.Description, terms),
+			ContentHighlight:     highlight(item.Content, terms),
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		// Newest first, then by UUID ascending
+		if !results[i].Item.PublishedDate.Equal(results[j].Item.PublishedDate) {
+			return results[i].Item.PublishedDate.After(results[j].Item.PublishedDate)
+		}
+		return results[i].Item.UUID.String() < results[j].Item.UUID.String()
+	})
+	if searchQuery.Offset >= len(results) {
+		return []*entity.ItemSearchResult{}, nil
+	}
+	results = results[searchQuery.Offset:]
+	if searchQuery.Limit > 0 && len(results) > searchQuery.Limit {
+		results = results[:searchQuery.Limit]
+	}
+	return results, nil
+}
+
+// Create adds valid item. Returns entity.ErrItemExists if item with the same UUID or canonical URL of publication is already stored.
+func (repository *Repository) Create(ctx context.Context, item *entity.Item) error {
+	repository.mu.Lock()
+	created := repository.create(item)
+	repository.mu.Unlock()
+	if !created {
+		return entity.ErrItemExists
+	}
+	repository.notifyInserted(item)
+	return nil
+}
+
+// CreateItems adds items, skipping already existing ones and the ones with stored canonical URL. Returns UUIDs of actually created items.
+func (repository *Repository) CreateItems(ctx context.Context, items []*entity.Item) ([]uuid.UUID, error) {
+	repository.mu.Lock()
+	created := []uuid.UUID{}
+	createdItems := []*entity.Item{}
+	for _, item := range items {
+		if repository.create(item) {
+			created = append(created, item.UUID)
+			createdItems = append(createdItems, item)
+		}
+	}
+	repository.mu.Unlock()
+	for _, item := range createdItems {
+		repository.notifyInserted(item)
+	}
+	return created, nil
+}
+
+// create stores item, if neither it nor item with its canonical URL exists. Must be called with lock held.
+func (repository *Repository) create(item *entity.Item) bool {
+	if _, ok := repository.records[item.UUID]; ok || repository.canonicalURLTaken(item) {
+		return false
+	}
+	now := time.Now()
+	repository.records[item.UUID] = &record{
+		item:       *copyItem(item),
+		state:      entity.ItemStateValid,
+		createdAt:  now,
+		modifiedAt: now,
+	}
+	repository.addEvent(itemevents.ItemCreatedType, newItemEventBody(item))
+	return true
+}
+
+// canonicalURLTaken checks if another item of publication has the canonical URL of item. Must be called with lock held.
+func (repository *Repository) canonicalURLTaken(item *entity.Item) bool {
+	if item.CanonicalURL == "" {
+		return false
+	}
+	for _, r := range repository.records {
+		if r.item.UUID != item.UUID && r.item.PublicationUUID == item.PublicationUUID && r.item.CanonicalURL == item.CanonicalURL {
+			return true
+		}
+	}
+	return false
+}
+
+// Update updates fields of existing item or creates it, if item doesn't exist yet, see postgresql.Repository.Update
+// Returns entity.ErrItemExists if another item of publication has the same canonical URL.
+func (repository *Repository) Update(ctx context.Context, item *entity.Item) error {
+	repository.mu.Lock()
+	r, ok := repository.records[item.UUID]
+	if !ok {
+		created := repository.create(item)
+		repository.mu.Unlock()
+		if !created {
+			return entity.ErrItemExists
+		}
+		repository.notifyInserted(item)
+		return nil
+	}
+	defer repository.mu.Unlock()
+	if r.item.PublishedDate.Equal(item.PublishedDate) && r.item.Title == item.Title &&
+		r.item.Description == item.Description && r.item.Content == item.Content && r.item.URL == item.URL && r.item.LanguageCode == item.LanguageCode &&
+		r.item.ContentText == item.ContentText && r.item.DeclaredLanguageCode == item.DeclaredLanguageCode && r.item.DetectedLanguageCode == item.DetectedLanguageCode &&
+		r.item.Fingerprint == item.Fingerprint && r.item.GUID == item.GUID && r.item.CanonicalURL == item.CanonicalURL {
+		return nil
+	}
+	if repository.canonicalURLTaken(item) {
+		return entity.ErrItemExists
+	}
+	r.item.PublishedDate = item.PublishedDate
+	r.item.Title = item.Title
+	r.item.Description = item.Description
+	r.item.Content = item.Content
+	r.item.URL = item.URL
+	r.item.LanguageCode = item.LanguageCode
+	r.item.ContentText = item.ContentText
+	r.item.DeclaredLanguageCode = item.DeclaredLanguageCode
+	r.item.DetectedLanguageCode = item.DetectedLanguageCode
+	r.item.Fingerprint = item.Fingerprint
+	r.item.GUID = item.GUID
+	r.item.CanonicalURL = item.CanonicalURL
+	r.modifiedAt = time.Now()
+	repository.addEvent(itemevents.ItemUpdatedType, newItemEventBody(item))
+	return nil
+}
+
+// LinkStoryCluster links item with the closest near-duplicate, see postgresql.Repository.LinkStoryCluster
+func (repository *Repository) LinkStoryCluster(ctx context.Context, item *entity.Item) (int64, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	item.ClusterID = 0
+	duplicate := repository.closestDuplicate(item)
+	if duplicate == nil {
+		// Item leaves its cluster
+		if r, ok := repository.records[item.UUID]; ok {
+			r.item.ClusterID = 0
+		}
+		return 0, nil
+	}
+	if duplicate.item.ClusterID == 0 {
+		repository.lastClusterID++
+		duplicate.item.ClusterID = repository.lastClusterID
+	}
+	if r, ok := repository.records[item.UUID]; ok {
+		r.item.ClusterID = duplicate.item.ClusterID
+	}
+	item.ClusterID = duplicate.item.ClusterID
+	return item.ClusterID, nil
+}
+
+// closestDuplicate returns record of the closest near-duplicate of item or nil. Must be called with lock held.
+func (repository *Repository) closestDuplicate(item *entity.Item) *record {
+	if item.Fingerprint == 0 {
+		return nil
+	}
+	var duplicate *record
+	for _, r := range repository.records {
+		if r.item.UUID == item.UUID || !entity.IsNearDuplicate(r.item.Fingerprint, item.Fingerprint) {
+			continue
+		}
+		if gap := r.item.PublishedDate.Sub(item.PublishedDate); gap > entity.NearDuplicateWindow || gap < -entity.NearDuplicateWindow {
+			continue
+		}
+		if duplicate == nil || closerDuplicate(&r.item, &duplicate.item, item.Fingerprint) {
+			duplicate = r
+		}
+	}
+	return duplicate
+}
+
+// closerDuplicate reports if item a is closer to fingerprint than b, ties are resolved by published date and UUID
+func closerDuplicate(a *entity.Item, b *entity.Item, fingerprint uint64) bool {
+	distanceA, distanceB := entity.FingerprintDistance(a.Fingerprint, fingerprint), entity.FingerprintDistance(b.Fingerprint, fingerprint)
+	if distanceA != distanceB {
+		return distanceA < distanceB
+	}
+	return cursorLess(entity.NewItemCursor(a), entity.NewItemCursor(b), true)
+}
+
+// GetItemsByClusterID returns up to limit valid items of story cluster, sorted by publishedDate and UUID
+func (repository *Repository) GetItemsByClusterID(ctx context.Context, clusterID int64, limit int) ([]*entity.Item, error) {
+	items := repository.filterItems(func(item *entity.Item) bool { return item.ClusterID == clusterID }, true)
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// ChangeItemState moves item to requested state and records the transition.
+// Returns recorded transition or nil if item doesn't exist.
+// If item is already in requested state, nothing is changed or recorded, returned transition has the same from and to states.
+func (repository *Repository) ChangeItemState(ctx context.Context, request *entity.ItemStateTransition) (*entity.ItemStateTransition, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	r, ok := repository.records[request.ItemUUID]
+	if !ok {
+		return nil, nil
+	}
+	transition := *request
+	transition.FromState = r.state
+	if !transition.Changed() {
+		return &transition, nil
+	}
+	if transition.ToState != entity.ItemStateValid && transition.ToState != entity.ItemStateDisabled {
+		return nil, fmt.Errorf("unknown item state %s", transition.ToState)
+	}
+	r.state = transition.ToState
+	r.modifiedAt = time.Now()
+	transition.CreatedAt = r.modifiedAt
+	repository.transitions = append(repository.transitions, transition)
+	repository.addEvent(itemevents.ItemStateChangedType, itemevents.ItemStateChangedBody{
+		UUID:      transition.ItemUUID,
+		FromState: string(transition.FromState),
+		ToState:   string(transition.ToState),
+		Reason:    transition.Reason,
+		Actor:     transition.Actor,
+	})
+	return &transition, nil
+}
+
+// Delete removes item
+func (repository *Repository) Delete(ctx context.Context, UUID uuid.UUID) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+	if _, ok := repository.records[UUID]; !ok {
+		return fmt.Errorf("item delete didn't delete record for UUID %s", UUID)
+	}
+	delete(repository.records, UUID)
+	return nil
+}
+
+// ItemExists checks if item with the same UUID is stored in any state
+func (repository *Repository) ItemExists(ctx context.Context, item *entity.Item) (bool, error) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+	_, ok := repository.records[item.UUID]
+	return ok, nil
+}
+
+// Healthcheck is always successful
+func (repository *Repository) Healthcheck(ctx context.Context) error {
+	return nil
+}
+
+// filterItems returns copies of valid items, matching filter, sorted by published date and UUID
+func (repository *Repository) filterItems(filter func(*entity.Item) bool, sortAsc bool) []*entity.Item {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+	items := []*entity.Item{}
+	for _, r := range repository.records {
+		if r.state == entity.ItemStateValid && filter(&r.item) {
+			items = append(items, copyItem(&r.item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return cursorLess(entity.NewItemCursor(items[i]), entity.NewItemCursor(items[j]), sortAsc)
+	})
+	return items
+}
+
+func byPublicationUUID(publicationUUID uuid.UUID) func(*entity.Item) bool {
+	return func(item *entity.Item) bool {
+		return item.PublicationUUID == publicationUUID
+	}
+}
+
+// cursorLess reports if cursor a goes before b in the sort order by published date and UUID
+func cursorLess(a *entity.ItemCursor, b *entity.ItemCursor, sortAsc bool) bool {
+	if !a.PublishedDate.Equal(b.PublishedDate) {
+		return a.PublishedDate.Before(b.PublishedDate) == sortAsc
+	}
+	if a.UUID == b.UUID {
+		return false
+	}
+	return (a.UUID.String() < b.UUID.String()) == sortAsc
+}
+
+// words splits text to lower case words
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// countMatches counts occurrences of terms in text
+func countMatches(text string, terms map[string]bool) map[string]int {
+	matches := map[string]int{}
+	for _, word := range words(text) {
+		if terms[word] {
+			matches[word]++
+		}
+	}
+	return matches
+}
+
+func sum(matches map[string]int) float64 {
+	total := 0
+	for _, n := range matches {
+		total += n
+	}
+	return float64(total)
+}
+
+// highlight wraps terms occurrences in text with <b></b> tags, as ts_headline does by default
+func highlight(text string, terms map[string]bool) string {
+	var b strings.Builder
+	word := []rune{}
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		if terms[strings.ToLower(string(word))] {
+			b.WriteString("<b>" + string(word) + "</b>")
+		} else {
+			b.WriteString(string(word))
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
@@ -0,0 +1,12 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/Tarick/naca-items/internal/repository/memory"
+	"github.com/Tarick/naca-items/internal/repository/repositorytest"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, memory.New())
+}
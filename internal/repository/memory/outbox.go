@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/pkg/itemevents"
+)
+
+// newItemEventBody returns body of ItemCreated and ItemUpdated events
+func newItemEventBody(item *entity.Item) itemevents.ItemBody {
+	return itemevents.ItemBody{
+		UUID:            item.UUID,
+		PublicationUUID: item.PublicationUUID,
+		PublishedDate:   item.PublishedDate,
+		Title:           item.Title,
+		Description:     item.Description,
+		Content:         item.Content,
+		URL:             item.URL,
+		LanguageCode:    item.LanguageCode,
+		ContentText:     item.ContentText,
+		GUID:            item.GUID,
+		CanonicalURL:    item.CanonicalURL,
+	}
+}
+
+// addEvent stores event in outbox. Must be called with lock held.
+// Body is kept as JSON, the same as PostgreSQL outbox returns it.
+func (repository *Repository) addEvent(eventType itemevents.EventType, body itemevents.EventBody) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		// Event bodies consist of strings, UUIDs and times, they are always marshalled
+		panic(err)
+	}
+	repository.lastEventID++
+	repository.outbox = append(repository.outbox, &itemevents.EventEnvelope{
+		ID:         repository.lastEventID,
+		Type:       eventType,
+		Metadata:   map[string]string{},
+		OccurredAt: time.Now(),
+		Msg:        json.RawMessage(payload),
+	})
+}
+
+// PublishOutboxEvents passes up to limit oldest events to publish one by one, see postgresql.Repository.PublishOutboxEvents.
+// Published events are removed, if publish fails, it and the rest of events stay in outbox.
+func (repository *Repository) PublishOutboxEvents(ctx context.Context, limit int, publish func(*itemevents.EventEnvelope) error) (int, error) {
+	repository.mu.RLock()
+	events := repository.outbox
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	events = append([]*itemevents.EventEnvelope{}, events...)
+	repository.mu.RUnlock()
+
+	published := map[int64]bool{}
+	var publishErr error
+	for _, event := range events {
+		if publishErr = publish(event); publishErr != nil {
+			break
+		}
+		published[event.ID] = true
+	}
+	repository.mu.Lock()
+	kept := repository.outbox[:0]
+	for _, event := range repository.outbox {
+		if !published[event.ID] {
+			kept = append(kept, event)
+		}
+	}
+	repository.outbox = kept
+	repository.mu.Unlock()
+	return len(published), publishErr
+}
@@ -0,0 +1,102 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// fingerprintBandsCondition matches items, which share at least one 8-bit band of fingerprint with $2.
+// Near-duplicates within entity.NearDuplicateDistance bits always share a band, expressions match bands indexes.
+var fingerprintBandsCondition = func() string {
+	conditions := make([]string, entity.NearDuplicateDistance+1)
+	for i := range conditions {
+		shift := 56 - 8*i
+		conditions[i] = fmt.Sprintf("((fingerprint >> %d) & 255) = (($2::bigint >> %d) & 255)", shift, shift)
+	}
+	return strings.Join(conditions, " or ")
+}()
+
+// LinkStoryCluster links item with the closest near-duplicate, published within entity.NearDuplicateWindow.
+// Near-duplicate cluster is used, new cluster is created if near-duplicate doesn't have it.
+// Item without fingerprint or near-duplicates leaves its cluster, so updated item is relinked by its new text.
+// Returns cluster ID, which is also set to item, or 0 if item has no fingerprint or near-duplicates.
+func (repository *Repository) LinkStoryCluster(ctx context.Context, item *entity.Item) (int64, error) {
+	query := fmt.Sprintf(`select uuid, cluster_id from items
+	where uuid<>$1 and fingerprint is not null
+		and published_date between $3::timestamptz - $4::interval and $3::timestamptz + $4::interval
+		and (%s)
+		and items_fingerprint_distance(fingerprint, $2) <= $5
+	order by items_fingerprint_distance(fingerprint, $2), published_date, uuid
+	limit 1
+	for update`, fingerprintBandsCondition)
+	span, ctx := repository.setupTracingSpan(ctx, "link-story-cluster", query)
+	defer span.Finish()
+	span.SetTag("item.UUID", item.UUID)
+	leaveQuery := "update items set cluster_id=null where uuid=$1 and cluster_id is not null"
+	item.ClusterID = 0
+	if item.Fingerprint == 0 {
+		if _, err := repository.pool.Exec(ctx, leaveQuery, item.UUID); err != nil {
+			span.Fail(err)
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	tx, err := repository.pool.Begin(ctx)
+	if err != nil {
+		span.Fail(err)
+		return 0, err
+	}
+	// Rollback is noop after commit
+	defer tx.Rollback(ctx)
+
+	var (
+		duplicateUUID uuid.UUID
+		clusterID     *int64
+	)
+	err = tx.QueryRow(ctx, query, item.UUID, int64(item.Fingerprint), item.PublishedDate, entity.NearDuplicateWindow, entity.NearDuplicateDistance).Scan(&duplicateUUID, &clusterID)
+	if err == pgx.ErrNoRows {
+		span.LogKV("event", "no near-duplicates")
+		if _, err := tx.Exec(ctx, leaveQuery, item.UUID); err != nil {
+			span.Fail(err)
+			return 0, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			span.Fail(err)
+			return 0, err
+		}
+		return 0, nil
+	}
+	if err != nil {
+		span.Fail(err)
+		return 0, err
+	}
+	if clusterID == nil {
+		clusterID = new(int64)
+		if err := tx.QueryRow(ctx, "insert into story_clusters default values returning id").Scan(clusterID); err != nil {
+			span.Fail(err)
+			return 0, err
+		}
+	}
+	if _, err := tx.Exec(ctx, "update items set cluster_id=$1 where uuid=any($2::uuid[]) and cluster_id is distinct from $1", *clusterID, []string{item.UUID.String(), duplicateUUID.String()}); err != nil {
+		span.Fail(err)
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		span.Fail(err)
+		return 0, err
+	}
+	span.LogKV("event", "linked story cluster", "cluster", *clusterID, "duplicate", duplicateUUID.String())
+	item.ClusterID = *clusterID
+	return *clusterID, nil
+}
+
+// GetItemsByClusterID returns up to limit valid items of story cluster, sorted by publishedDate and UUID
+func (repository *Repository) GetItemsByClusterID(ctx context.Context, clusterID int64, limit int) ([]*entity.Item, error) {
+	return repository.getItems(ctx, sqlQueryItem+" join item_state is2 on items.state_id=is2.id where is2.type='valid' and cluster_id=$1 order by published_date, uuid limit $2", clusterID, limit)
+}
@@ -0,0 +1,96 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+)
+
+const (
+	// itemEventsChannel is notification channel of items changes, see migration 005_item_events_notify
+	itemEventsChannel = "item_events"
+	// listenRetryDelay is the pause before reconnecting after listening failure
+	listenRetryDelay = 5 * time.Second
+)
+
+// Logger interface
+type Logger interface {
+	Debug(args ...interface{})
+	Info(args ...interface{})
+	Warn(args ...interface{})
+	Error(args ...interface{})
+	Fatal(args ...interface{})
+}
+
+// ItemEventHandler is called for each item event. Nil event means that events could have been missed,
+// e.g. after reconnection, so handler should resynchronize its state.
+type ItemEventHandler func(*entity.ItemEvent)
+
+// ItemEventsListener receives items changes notifications with LISTEN on dedicated pool connection
+type ItemEventsListener struct {
+	repository *Repository
+	logger     Logger
+	handlers   []ItemEventHandler
+}
+
+// NewItemEventsListener creates listener of items changes
+func (repository *Repository) NewItemEventsListener(logger Logger) *ItemEventsListener {
+	return &ItemEventsListener{repository: repository, logger: logger}
+}
+
+// Subscribe adds handler of events, must be called before Run
+func (l *ItemEventsListener) Subscribe(handler ItemEventHandler) {
+	l.handlers = append(l.handlers, handler)
+}
+
+// Run listens for events until context is done, reconnecting on failures
+func (l *ItemEventsListener) Run(ctx context.Context) error {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		l.logger.Error("Failure listening to item events, reconnecting in ", listenRetryDelay, ": ", err)
+		l.dispatch(nil)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (l *ItemEventsListener) listen(ctx context.Context) error {
+	conn, err := l.repository.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, "listen "+itemEventsChannel); err != nil {
+		return err
+	}
+	l.logger.Info("Listening to item events")
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			// Connection state is unknown after interrupted wait, don't return it to the pool
+			conn.Conn().Close(context.Background())
+			return err
+		}
+		event := &entity.ItemEvent{}
+		if err := json.Unmarshal([]byte(notification.Payload), event); err != nil {
+			l.logger.Error("Failure unmarshalling item event ", notification.Payload, ": ", err)
+			continue
+		}
+		l.logger.Debug("Received item event ", event.Operation, " of item ", event.UUID)
+		l.dispatch(event)
+	}
+}
+
+func (l *ItemEventsListener) dispatch(event *entity.ItemEvent) {
+	for _, handle := range l.handlers {
+		handle(event)
+	}
+}
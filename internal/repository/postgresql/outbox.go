@@ -0,0 +1,152 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/pkg/itemevents"
+	"github.com/opentracing/opentracing-go"
+	otLog "github.com/opentracing/opentracing-go/log"
+)
+
+// outboxClaimTimeout is the time events are claimed by relay for publishing, after that they are published by any relay
+const outboxClaimTimeout = time.Minute
+
+// newItemEventBody returns body of ItemCreated and ItemUpdated events
+func newItemEventBody(item *entity.Item) itemevents.ItemBody {
+	return itemevents.ItemBody{
+		UUID:            item.UUID,
+		PublicationUUID: item.PublicationUUID,
+		PublishedDate:   item.PublishedDate,
+		Title:           item.Title,
+		Description:     item.Description,
+		Content:         item.Content,
+		URL:             item.URL,
+		LanguageCode:    item.LanguageCode,
+		ContentText:     item.ContentText,
+		GUID:            item.GUID,
+		CanonicalURL:    item.CanonicalURL,
+	}
+}
+
+// outboxRecord returns JSON of event body and metadata to store in outbox together with item change.
+// Metadata carries span context in the format of message envelope metadata, so event consumers continue the trace.
+func (repository *Repository) outboxRecord(span opentracing.Span, body itemevents.EventBody) (payload string, metadata string, err error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", "", err
+	}
+	carrier := map[string]string{}
+	if err := repository.tracer.Inject(span.Context(), opentracing.TextMap, opentracing.TextMapCarrier(carrier)); err != nil {
+		return "", "", err
+	}
+	metadataData, err := json.Marshal(carrier)
+	if err != nil {
+		return "", "", err
+	}
+	return string(data), string(metadataData), nil
+}
+
+// PublishOutboxEvents claims up to limit oldest unclaimed events in outbox for outboxClaimTimeout, skipping events
+// locked by other relays, and passes them to publish one by one outside of transaction.
+// Published events are deleted and the rest are released in the second short transaction.
+// If publish fails, it and the rest of events stay in outbox, and the number of published events is returned with the error.
+// Events could be published again if deletion fails or the claim expires before it.
+// Events are published in the order of writing by single relay only, with several relays events of the same item
+// could be published out of order.
+func (repository *Repository) PublishOutboxEvents(ctx context.Context, limit int, publish func(*itemevents.EventEnvelope) error) (int, error) {
+	query := `update item_events_outbox set claimed_until = now() + $2 * interval '1 second'
+		where id in (select id from item_events_outbox where claimed_until is null or claimed_until < now()
+			order by id limit $1 for update skip locked)
+		returning id, event_type, payload, metadata, created_at`
+	span, ctx := repository.setupTracingSpan(ctx, "publish-outbox-events", query)
+	defer span.Finish()
+
+	events, err := repository.claimOutboxEvents(ctx, query, limit)
+	if err != nil {
+		span.Fail(err)
+		return 0, err
+	}
+
+	published := []int64{}
+	unpublished := []int64{}
+	var publishErr error
+	for _, event := range events {
+		if publishErr == nil {
+			if publishErr = publish(event); publishErr != nil {
+				span.Fail(publishErr)
+			}
+		}
+		if publishErr != nil {
+			unpublished = append(unpublished, event.ID)
+			continue
+		}
+		published = append(published, event.ID)
+	}
+	if len(events) > 0 {
+		// Context of publishing could be cancelled already, claims are resolved anyway
+		if err := repository.resolveOutboxClaims(context.Background(), published, unpublished); err != nil {
+			span.Fail(err)
+			return 0, err
+		}
+	}
+	span.LogFields(
+		otLog.Int("publishedNumber", len(published)),
+	)
+	return len(published), publishErr
+}
+
+// claimOutboxEvents runs claim query and returns claimed events ordered by id
+func (repository *Repository) claimOutboxEvents(ctx context.Context, query string, limit int) ([]*itemevents.EventEnvelope, error) {
+	rows, err := repository.pool.Query(ctx, query, limit, int(outboxClaimTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	events := []*itemevents.EventEnvelope{}
+	for rows.Next() {
+		var (
+			event    = &itemevents.EventEnvelope{}
+			payload  []byte
+			metadata []byte
+		)
+		if err := rows.Scan(&event.ID, &event.Type, &payload, &metadata, &event.OccurredAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(metadata, &event.Metadata); err != nil {
+			return nil, err
+		}
+		event.Msg = json.RawMessage(payload)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// Update doesn't keep order of subquery
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+// resolveOutboxClaims deletes published events and releases claims of unpublished ones, so they are retried without delay
+func (repository *Repository) resolveOutboxClaims(ctx context.Context, published []int64, unpublished []int64) error {
+	tx, err := repository.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// Rollback is noop after commit
+	defer tx.Rollback(ctx)
+	if len(published) > 0 {
+		if _, err := tx.Exec(ctx, "delete from item_events_outbox where id = any($1)", published); err != nil {
+			return err
+		}
+	}
+	if len(unpublished) > 0 {
+		if _, err := tx.Exec(ctx, "update item_events_outbox set claimed_until = null where id = any($1)", unpublished); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
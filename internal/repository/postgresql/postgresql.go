@@ -4,21 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/pkg/itemevents"
 	opentracing "github.com/opentracing/opentracing-go"
 	otLog "github.com/opentracing/opentracing-go/log"
 
 	"go.uber.org/zap"
 
 	"github.com/gofrs/uuid"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/log/zapadapter"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// uniqueViolationCode is PostgreSQL error code of unique constraint violation
+const uniqueViolationCode = "23505"
+
 const (
-	sqlQueryItem string = "select uuid, publication_uuid, published_date, title, description, content, url, language_code from items "
+	sqlQueryItem string = "select uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, coalesce(cluster_id, 0), guid, canonical_url from items "
 )
 
 // Config defines database configuration, usable for Viper
@@ -34,6 +41,13 @@ type Config struct {
 	MaxConnections int32  `mapstructure:"max_connections"`
 }
 
+const (
+	// schemaVersion is the last migration repository queries depend on, must be updated with new migrations
+	schemaVersion = 12
+	// migrationsTable is the tern version table, see migrations/tern.conf
+	migrationsTable = "public.migrations"
+)
+
 // Repository is the main repository struct
 // Use Repository.pool to make queries
 type Repository struct {
@@ -73,12 +87,21 @@ func New(databaseConfig *Config, logger pgx.Logger, tracer opentracing.Tracer) (
 	if err != nil {
 		return nil, err
 	}
+	if err := prometheus.Register(newPoolCollector(pool, databaseConfig.Name)); err != nil {
+		pool.Close()
+		return nil, err
+	}
 	return &Repository{pool: pool, tracer: tracer}, nil
 }
 
+// Close closes all pool connections, waiting for acquired connections to be released
+func (repository *Repository) Close() {
+	repository.pool.Close()
+}
+
 // GetItemByUUID returns item found by UUID
 func (repository *Repository) GetItemByUUID(ctx context.Context, UUID uuid.UUID) (*entity.Item, error) {
-	query := "select uuid, publication_uuid, published_date, title, description, content, url, language_code from items join item_state is2 on items.state_id=is2.id where is2.type='valid' and uuid=$1"
+	query := "select uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, coalesce(cluster_id, 0), guid, canonical_url from items join item_state is2 on items.state_id=is2.id where is2.type='valid' and uuid=$1"
 	span, ctx := repository.setupTracingSpan(ctx, "get-item-by-uuid", query)
 	defer span.Finish()
 	span.SetTag("item.UUID", UUID)
@@ -93,17 +116,19 @@ func (repository *Repository) GetItemByUUID(ctx context.Context, UUID uuid.UUID)
 		&item.Content,
 		&item.URL,
 		&item.LanguageCode,
+		&item.ContentText,
+		&item.DeclaredLanguageCode,
+		&item.DetectedLanguageCode,
+		&item.ClusterID,
+		&item.GUID,
+		&item.CanonicalURL,
 	)
 	if err != nil && err == pgx.ErrNoRows {
-		span.LogFields(
-			otLog.Error(err),
-		)
+		span.LogKV("event", "item not found")
 		return nil, nil
 	}
 	if err != nil {
-		span.LogFields(
-			otLog.Error(err),
-		)
+		span.Fail(err)
 		return nil, err
 	}
 	span.LogKV("event", "fetched item")
@@ -112,7 +137,7 @@ func (repository *Repository) GetItemByUUID(ctx context.Context, UUID uuid.UUID)
 
 // GetItems returns slice of items pointers
 func (repository *Repository) GetItems(ctx context.Context) ([]*entity.Item, error) {
-	return repository.getItems(ctx, sqlQueryItem)
+	return repository.getItems(ctx, sqlQueryItem+" join item_state is2 on items.state_id=is2.id where is2.type='valid'")
 }
 
 // GetItemsByPublicationUUID returns slice of items pointers filtered by PublicationUUID
@@ -131,6 +156,140 @@ func (repository *Repository) GetItemsByPublicationUUIDSortByPublishedDate(ctx c
 	return repository.getItems(ctx, queryString, publicationUUID)
 }
 
+// GetItemsPageByPublicationUUID returns page of items filtered by PublicationUUID and sorted by publishedDate and UUID.
+// Keyset pagination is used: only items strictly after 'after' and strictly before 'before' cursors (in sort order) are returned, nil cursors are ignored.
+// Positive limit restricts number of returned items, they are taken from the start of the list or, if fromEnd is set, from its end.
+// Returned items are always in the requested sort order.
+func (repository *Repository) GetItemsPageByPublicationUUID(ctx context.Context, publicationUUID uuid.UUID, sortAsc bool, after *entity.ItemCursor, before *entity.ItemCursor, limit int, fromEnd bool) ([]*entity.Item, error) {
+	afterComparison, beforeComparison := "<", ">"
+	if sortAsc {
+		afterComparison, beforeComparison = ">", "<"
+	}
+	args := []interface{}{publicationUUID}
+	queryString := sqlQueryItem + " join item_state is2 on items.state_id=is2.id where is2.type='valid' and publication_uuid=$1"
+	if after != nil {
+		args = append(args, after.PublishedDate, after.UUID)
+		queryString += fmt.Sprintf(" and (published_date, uuid) %s ($%d, $%d)", afterComparison, len(args)-1, len(args))
+	}
+	if before != nil {
+		args = append(args, before.PublishedDate, before.UUID)
+		queryString += fmt.Sprintf(" and (published_date, uuid) %s ($%d, $%d)", beforeComparison, len(args)-1, len(args))
+	}
+	// Taking items from the end is done by reversing the order in query and then reversing fetched items
+	queryAsc := sortAsc != fromEnd
+	sortOrder := "desc"
+	if queryAsc {
+		sortOrder = "asc"
+	}
+	queryString += fmt.Sprintf(" order by published_date %s, uuid %s", sortOrder, sortOrder)
+	if limit > 0 {
+		args = append(args, limit)
+		queryString += fmt.Sprintf(" limit $%d", len(args))
+	}
+	items, err := repository.getItems(ctx, queryString, args...)
+	if err != nil {
+		return nil, err
+	}
+	if fromEnd {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	return items, nil
+}
+
+// CountItemsByPublicationUUID returns number of items with PublicationUUID
+func (repository *Repository) CountItemsByPublicationUUID(ctx context.Context, publicationUUID uuid.UUID) (int, error) {
+	query := "select count(*) from items join item_state is2 on items.state_id=is2.id where is2.type='valid' and publication_uuid=$1"
+	span, ctx := repository.setupTracingSpan(ctx, "count-items", query)
+	defer span.Finish()
+	span.SetTag("item.PublicationUUID", publicationUUID)
+
+	var count int
+	if err := repository.pool.QueryRow(ctx, query, publicationUUID).Scan(&count); err != nil {
+		span.Fail(err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// SearchItems returns items, matching full-text search query, ordered by rank.
+// With query language code search uses its text search configuration, otherwise the configuration of each item language is used.
+func (repository *Repository) SearchItems(ctx context.Context, searchQuery *entity.ItemSearchQuery) ([]*entity.ItemSearchResult, error) {
+	args := []interface{}{searchQuery.Query}
+	tsQuery := "websearch_to_tsquery(items_text_search_config(items.language_code), $1)"
+	conditions := ""
+	if searchQuery.LanguageCode != "" {
+		args = append(args, searchQuery.LanguageCode)
+		// Constant query allows to use the index
+		tsQuery = fmt.Sprintf("websearch_to_tsquery(items_text_search_config($%d::varchar), $1)", len(args))
+		conditions += fmt.Sprintf(" and language_code=$%d", len(args))
+	}
+	if searchQuery.PublicationUUID != uuid.Nil {
+		args = append(args, searchQuery.PublicationUUID)
+		conditions += fmt.Sprintf(" and publication_uuid=$%d", len(args))
+	}
+	args = append(args, searchQuery.Limit, searchQuery.Offset)
+	// Highlighting is expensive, so it is done only for the page of found items
+	query := fmt.Sprintf(`with found as (
+		select uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, coalesce(cluster_id, 0) as cluster_id, guid, canonical_url, q.query, ts_rank_cd(search_vector, q.query) as rank
+		from items join item_state is2 on items.state_id=is2.id, lateral (select %s as query) q
+		where is2.type='valid' and search_vector @@ q.query%s
+		order by rank desc, published_date desc, uuid
+		limit $%d offset $%d)
+	select uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, cluster_id, guid, canonical_url, rank,
+		ts_headline(items_text_search_config(language_code), title, query, 'HighlightAll=true'),
+		ts_headline(items_text_search_config(language_code), coalesce(description, ''), query, 'MaxFragments=2, MaxWords=30, MinWords=10'),
+		ts_headline(items_text_search_config(language_code), coalesce(content, ''), query, 'MaxFragments=3, MaxWords=30, MinWords=10')
+	from found order by rank desc, published_date desc, uuid`, tsQuery, conditions, len(args)-1, len(args))
+	span, ctx := repository.setupTracingSpan(ctx, "search-items", query)
+	defer span.Finish()
+	span.SetTag("search.query", searchQuery.Query)
+
+	rows, err := repository.pool.Query(ctx, query, args...)
+	if err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*entity.ItemSearchResult{}
+	for rows.Next() {
+		result := &entity.ItemSearchResult{Item: entity.NewItem()}
+		if err := rows.Scan(
+			&result.Item.UUID,
+			&result.Item.PublicationUUID,
+			&result.Item.PublishedDate,
+			&result.Item.Title,
+			&result.Item.Description,
+			&result.Item.Content,
+			&result.Item.URL,
+			&result.Item.LanguageCode,
+			&result.Item.ContentText,
+			&result.Item.DeclaredLanguageCode,
+			&result.Item.DetectedLanguageCode,
+			&result.Item.ClusterID,
+			&result.Item.GUID,
+			&result.Item.CanonicalURL,
+			&result.Rank,
+			&result.TitleHighlight,
+			&result.DescriptionHighlight,
+			&result.ContentHighlight); err != nil {
+			span.Fail(err)
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	span.LogFields(
+		otLog.Int("itemsNumber", len(results)),
+	)
+	return results, nil
+}
+
 // getItems returns slice of items pointers, retrieved using queryString with any parameters
 func (repository *Repository) getItems(ctx context.Context, queryString string, args ...interface{}) ([]*entity.Item, error) {
 	span, ctx := repository.setupTracingSpan(ctx, "get-items", queryString)
@@ -138,9 +297,7 @@ func (repository *Repository) getItems(ctx context.Context, queryString string,
 
 	rows, err := repository.pool.Query(ctx, queryString, args...)
 	if err != nil {
-		span.LogFields(
-			otLog.Error(err),
-		)
+		span.Fail(err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -156,15 +313,19 @@ func (repository *Repository) getItems(ctx context.Context, queryString string,
 			&item.Description,
 			&item.Content,
 			&item.URL,
-			&item.LanguageCode); err != nil {
+			&item.LanguageCode,
+			&item.ContentText,
+			&item.DeclaredLanguageCode,
+			&item.DetectedLanguageCode,
+			&item.ClusterID,
+			&item.GUID,
+			&item.CanonicalURL); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
 	}
 	if rows.Err() != nil {
-		span.LogFields(
-			otLog.Error(err),
-		)
+		span.Fail(err)
 		return nil, err
 	}
 	span.LogKV("event", "fetched items")
@@ -174,21 +335,232 @@ func (repository *Repository) getItems(ctx context.Context, queryString string,
 	return items, nil
 }
 
+// Create adds item to repository. Returns entity.ErrItemExists if item with the same UUID or canonical URL of publication is already stored.
 func (repository *Repository) Create(ctx context.Context, item *entity.Item) error {
-	query := "insert into items (uuid, publication_uuid, published_date, title, description, content, url, language_code, state_id) select $1, $2, $3, $4, $5, $6, $7, $8, id from item_state where type='valid'"
+	query := `with created as (
+		insert into items (uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, fingerprint, guid, canonical_url, state_id) select $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, nullif($12::bigint, 0), $13, $14, id from item_state where type='valid' on conflict do nothing returning uuid
+	)
+	insert into item_events_outbox (event_type, item_uuid, payload, metadata) select $15::int, uuid, $16::jsonb, $17::jsonb from created returning item_uuid`
 	span, ctx := repository.setupTracingSpan(ctx, "create-item", query)
 	defer span.Finish()
 	span.SetTag("item.UUID", item.UUID)
 	span.SetTag("item.PublicationUUID", item.PublicationUUID)
-	_, err := repository.pool.Exec(ctx, query, item.UUID, item.PublicationUUID, item.PublishedDate, item.Title, item.Description, item.Content, item.URL, item.LanguageCode)
+	payload, metadata, err := repository.outboxRecord(span, newItemEventBody(item))
+	if err != nil {
+		span.Fail(err)
+		return err
+	}
+	var created uuid.UUID
+	err = repository.pool.QueryRow(ctx, query, item.UUID, item.PublicationUUID, item.PublishedDate, item.Title, item.Description, item.Content, item.URL, item.LanguageCode,
+		item.ContentText, item.DeclaredLanguageCode, item.DetectedLanguageCode, int64(item.Fingerprint), item.GUID, item.CanonicalURL,
+		itemevents.ItemCreatedType, payload, metadata).Scan(&created)
+	if err == pgx.ErrNoRows {
+		span.LogKV("event", "item exists")
+		return entity.ErrItemExists
+	}
 	if err != nil {
-		span.LogFields(
-			otLog.Error(err),
-		)
+		span.Fail(err)
 	}
 	return err
 }
 
+// CreateItems adds items with a single query, skipping already existing ones and the ones with already stored canonical URL of publication.
+// Returns UUIDs of actually created items.
+func (repository *Repository) CreateItems(ctx context.Context, items []*entity.Item) ([]uuid.UUID, error) {
+	query := `with created as (
+		insert into items (uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, fingerprint, guid, canonical_url, state_id)
+		select i.uuid, i.publication_uuid, i.published_date, i.title, i.description, i.content, i.url, i.language_code, i.content_text, i.declared_language_code, i.detected_language_code, nullif(i.fingerprint, 0), i.guid, i.canonical_url, s.id
+		from unnest($1::uuid[], $2::uuid[], $3::timestamptz[], $4::text[], $5::text[], $6::text[], $7::text[], $8::varchar[], $9::text[], $10::varchar[], $11::varchar[], $12::bigint[], $13::text[], $14::text[])
+			as i(uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, fingerprint, guid, canonical_url),
+			item_state s
+		where s.type='valid'
+		on conflict do nothing
+		returning uuid
+	)
+	insert into item_events_outbox (event_type, item_uuid, payload, metadata)
+	select $15::int, created.uuid, e.payload::jsonb, $17::jsonb
+	from created join unnest($1::uuid[], $16::text[]) as e(uuid, payload) on e.uuid = created.uuid
+	returning item_uuid`
+	span, ctx := repository.setupTracingSpan(ctx, "create-items", query)
+	defer span.Finish()
+	span.SetTag("items.number", len(items))
+
+	var (
+		uuids                 = make([]string, len(items))
+		publicationUUIDs      = make([]string, len(items))
+		publishedDates        = make([]time.Time, len(items))
+		titles                = make([]string, len(items))
+		descriptions          = make([]string, len(items))
+		contents              = make([]string, len(items))
+		urls                  = make([]string, len(items))
+		languageCodes         = make([]string, len(items))
+		contentTexts          = make([]string, len(items))
+		declaredLanguageCodes = make([]string, len(items))
+		detectedLanguageCodes = make([]string, len(items))
+		fingerprints          = make([]int64, len(items))
+		guids                 = make([]string, len(items))
+		canonicalURLs         = make([]string, len(items))
+		payloads              = make([]string, len(items))
+		metadata              string
+		err                   error
+	)
+	for i, item := range items {
+		uuids[i] = item.UUID.String()
+		publicationUUIDs[i] = item.PublicationUUID.String()
+		publishedDates[i] = item.PublishedDate
+		titles[i] = item.Title
+		descriptions[i] = item.Description
+		contents[i] = item.Content
+		urls[i] = item.URL
+		languageCodes[i] = item.LanguageCode
+		contentTexts[i] = item.ContentText
+		declaredLanguageCodes[i] = item.DeclaredLanguageCode
+		detectedLanguageCodes[i] = item.DetectedLanguageCode
+		fingerprints[i] = int64(item.Fingerprint)
+		guids[i] = item.GUID
+		canonicalURLs[i] = item.CanonicalURL
+		if payloads[i], metadata, err = repository.outboxRecord(span, newItemEventBody(item)); err != nil {
+			span.Fail(err)
+			return nil, err
+		}
+	}
+	rows, err := repository.pool.Query(ctx, query, uuids, publicationUUIDs, publishedDates, titles, descriptions, contents, urls, languageCodes,
+		contentTexts, declaredLanguageCodes, detectedLanguageCodes, fingerprints, guids, canonicalURLs,
+		itemevents.ItemCreatedType, payloads, metadata)
+	if err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	defer rows.Close()
+	created := []uuid.UUID{}
+	for rows.Next() {
+		var u uuid.UUID
+		if err := rows.Scan(&u); err != nil {
+			span.Fail(err)
+			return nil, err
+		}
+		created = append(created, u)
+	}
+	if err := rows.Err(); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	span.LogFields(
+		otLog.Int("createdNumber", len(created)),
+	)
+	return created, nil
+}
+
+// Update updates fields of existing item or creates it, if item doesn't exist yet. Title and published date are updated too,
+// since they are not identity of items of publications with GUID or URL identity strategy.
+// Item is not touched (and its modified_at is kept) if fields are not changed.
+// Returns entity.ErrItemExists if another item of publication has the same canonical URL.
+func (repository *Repository) Update(ctx context.Context, item *entity.Item) error {
+	query := `with upserted as (
+		insert into items (uuid, publication_uuid, published_date, title, description, content, url, language_code, content_text, declared_language_code, detected_language_code, fingerprint, guid, canonical_url, state_id)
+		select $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, nullif($12::bigint, 0), $13, $14, id from item_state where type='valid'
+		on conflict (uuid) do update set published_date=excluded.published_date, title=excluded.title, description=excluded.description, content=excluded.content,
+			url=excluded.url, language_code=excluded.language_code, content_text=excluded.content_text, declared_language_code=excluded.declared_language_code,
+			detected_language_code=excluded.detected_language_code, fingerprint=excluded.fingerprint, guid=excluded.guid, canonical_url=excluded.canonical_url
+		where (items.published_date, items.title, items.description, items.content, items.url, items.language_code, items.content_text, items.declared_language_code,
+				items.detected_language_code, items.fingerprint, items.guid, items.canonical_url)
+			is distinct from (excluded.published_date, excluded.title, excluded.description, excluded.content, excluded.url, excluded.language_code, excluded.content_text,
+				excluded.declared_language_code, excluded.detected_language_code, excluded.fingerprint, excluded.guid, excluded.canonical_url)
+		returning uuid, (xmax = 0) as inserted
+	)
+	insert into item_events_outbox (event_type, item_uuid, payload, metadata)
+	select case when inserted then $15::int else $16::int end, uuid, $17::jsonb, $18::jsonb from upserted`
+	span, ctx := repository.setupTracingSpan(ctx, "update-item", query)
+	defer span.Finish()
+	span.SetTag("item.UUID", item.UUID)
+	span.SetTag("item.PublicationUUID", item.PublicationUUID)
+	payload, metadata, err := repository.outboxRecord(span, newItemEventBody(item))
+	if err != nil {
+		span.Fail(err)
+		return err
+	}
+	_, err = repository.pool.Exec(ctx, query, item.UUID, item.PublicationUUID, item.PublishedDate, item.Title, item.Description, item.Content, item.URL, item.LanguageCode,
+		item.ContentText, item.DeclaredLanguageCode, item.DetectedLanguageCode, int64(item.Fingerprint), item.GUID, item.CanonicalURL,
+		itemevents.ItemCreatedType, itemevents.ItemUpdatedType, payload, metadata)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		span.LogKV("event", "item exists")
+		return entity.ErrItemExists
+	}
+	if err != nil {
+		span.Fail(err)
+	}
+	return err
+}
+
+// ChangeItemState moves item to requested state and records the transition with its reason and actor.
+// Returns recorded transition or nil if item doesn't exist.
+// If item is already in requested state, nothing is changed or recorded, returned transition has the same from and to states.
+func (repository *Repository) ChangeItemState(ctx context.Context, request *entity.ItemStateTransition) (*entity.ItemStateTransition, error) {
+	query := "update items set state_id=(select id from item_state where type=$2) where uuid=$1"
+	span, ctx := repository.setupTracingSpan(ctx, "change-item-state", query)
+	defer span.Finish()
+	span.SetTag("item.UUID", request.ItemUUID)
+	span.SetTag("item.state", request.ToState)
+
+	tx, err := repository.pool.Begin(ctx)
+	if err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	// Rollback is noop after commit
+	defer tx.Rollback(ctx)
+
+	transition := *request
+	var fromState string
+	err = tx.QueryRow(ctx, "select is2.type from items join item_state is2 on items.state_id=is2.id where uuid=$1 for update of items", request.ItemUUID).Scan(&fromState)
+	if err == pgx.ErrNoRows {
+		span.LogKV("event", "item not found")
+		return nil, nil
+	}
+	if err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	transition.FromState = entity.ItemState(fromState)
+	if !transition.Changed() {
+		span.LogKV("event", "item is already in requested state")
+		return &transition, nil
+	}
+	if _, err := tx.Exec(ctx, query, transition.ItemUUID, string(transition.ToState)); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	if err := tx.QueryRow(ctx, `insert into item_state_transitions (item_uuid, from_state_id, to_state_id, reason, actor)
+	select $1, f.id, t.id, $4, $5 from item_state f, item_state t where f.type=$2 and t.type=$3 returning created_at`,
+		transition.ItemUUID, string(transition.FromState), string(transition.ToState), transition.Reason, transition.Actor).Scan(&transition.CreatedAt); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	payload, metadata, err := repository.outboxRecord(span, itemevents.ItemStateChangedBody{
+		UUID:      transition.ItemUUID,
+		FromState: string(transition.FromState),
+		ToState:   string(transition.ToState),
+		Reason:    transition.Reason,
+		Actor:     transition.Actor,
+	})
+	if err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, "insert into item_events_outbox (event_type, item_uuid, payload, metadata) values ($1::int, $2, $3::jsonb, $4::jsonb)",
+		itemevents.ItemStateChangedType, transition.ItemUUID, payload, metadata); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	span.LogKV("event", "changed item state")
+	return &transition, nil
+}
+
 func (repository *Repository) Delete(ctx context.Context, UUID uuid.UUID) error {
 	query := "delete from items where uuid=$1"
 	span, ctx := repository.setupTracingSpan(ctx, "delete-item-by-uuid", query)
@@ -196,9 +568,7 @@ func (repository *Repository) Delete(ctx context.Context, UUID uuid.UUID) error
 	span.SetTag("item.UUID", UUID)
 	result, err := repository.pool.Exec(ctx, query, UUID)
 	if err != nil {
-		span.LogFields(
-			otLog.Error(err),
-		)
+		span.Fail(err)
 		return err
 	}
 	if result.RowsAffected() != 1 {
@@ -216,9 +586,7 @@ func (repository *Repository) ItemExists(ctx context.Context, item *entity.Item)
 	var exists bool
 	row := repository.pool.QueryRow(ctx, query, item.UUID)
 	if err := row.Scan(&exists); err != nil {
-		span.LogFields(
-			otLog.Error(err),
-		)
+		span.Fail(err)
 		return false, err
 	}
 	if exists == true {
@@ -228,23 +596,31 @@ func (repository *Repository) ItemExists(ctx context.Context, item *entity.Item)
 	return false, nil
 }
 
-// Healthcheck is needed for application healtchecks
+// Healthcheck is needed for application healtchecks, it checks access to 'items' table, which may be empty
 func (repository *Repository) Healthcheck(ctx context.Context) error {
-	var exists bool
-	row := repository.pool.QueryRow(ctx, "select exists (select 1 from items limit 1)")
-	if err := row.Scan(&exists); err != nil {
-		return err
+	if _, err := repository.pool.Exec(ctx, "select 1 from items limit 1"); err != nil {
+		return fmt.Errorf("failure checking access to 'items' table: %w", err)
 	}
-	if exists {
-		return nil
+	return nil
+}
+
+// CheckSchemaVersion checks that database migrations are applied at least up to the schema version repository relies on
+func (repository *Repository) CheckSchemaVersion(ctx context.Context) error {
+	var version int
+	if err := repository.pool.QueryRow(ctx, "select version from "+migrationsTable).Scan(&version); err != nil {
+		return fmt.Errorf("failure reading schema version: %w", err)
+	}
+	if version < schemaVersion {
+		return fmt.Errorf("schema version %d is lower than required %d, apply migrations", version, schemaVersion)
 	}
-	return fmt.Errorf("failure checking access to 'items' table")
+	return nil
 }
 
-func (repository *Repository) setupTracingSpan(ctx context.Context, name string, query string) (opentracing.Span, context.Context) {
+// setupTracingSpan starts span of repository operation, span name is used as operation label in metrics
+func (repository *Repository) setupTracingSpan(ctx context.Context, name string, query string) (*operationSpan, context.Context) {
 	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, repository.tracer, name)
 	span.SetTag("component", "repository")
 	span.SetTag("db.type", "sql")
 	span.SetTag("db.query", query)
-	return span, ctx
+	return &operationSpan{Span: span, operation: name, start: time.Now()}, ctx
 }
@@ -0,0 +1,166 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	otLog "github.com/opentracing/opentracing-go/log"
+)
+
+// GetPublicationUUIDs returns UUIDs of publications, which have items in any state
+func (repository *Repository) GetPublicationUUIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query := "select distinct publication_uuid from items order by publication_uuid"
+	span, ctx := repository.setupTracingSpan(ctx, "get-publication-uuids", query)
+	defer span.Finish()
+
+	rows, err := repository.pool.Query(ctx, query)
+	if err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	defer rows.Close()
+	publications := []uuid.UUID{}
+	for rows.Next() {
+		var publicationUUID uuid.UUID
+		if err := rows.Scan(&publicationUUID); err != nil {
+			span.Fail(err)
+			return nil, err
+		}
+		publications = append(publications, publicationUUID)
+	}
+	if err := rows.Err(); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	return publications, nil
+}
+
+// GetItemIdentities returns publication items in any state with fields, used by identity strategies, sorted by publishedDate and UUID
+func (repository *Repository) GetItemIdentities(ctx context.Context, publicationUUID uuid.UUID) ([]*entity.Item, error) {
+	query := "select uuid, publication_uuid, published_date, title, url, guid from items where publication_uuid=$1 order by published_date, uuid"
+	span, ctx := repository.setupTracingSpan(ctx, "get-item-identities", query)
+	defer span.Finish()
+	span.SetTag("item.PublicationUUID", publicationUUID)
+
+	rows, err := repository.pool.Query(ctx, query, publicationUUID)
+	if err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*entity.Item{}
+	for rows.Next() {
+		item := entity.NewItem()
+		var url *string
+		if err := rows.Scan(&item.UUID, &item.PublicationUUID, &item.PublishedDate, &item.Title, &url, &item.GUID); err != nil {
+			span.Fail(err)
+			return nil, err
+		}
+		if url != nil {
+			item.URL = *url
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
+	span.LogFields(
+		otLog.Int("itemsNumber", len(items)),
+	)
+	return items, nil
+}
+
+// RemapItemUUID changes item UUID, state transitions follow the item and unpublished events in outbox are rewritten
+// to the new UUID in the same transaction. Cluster membership is stored with the item and is kept.
+// Returns entity.ErrItemExists if another item already has the new UUID
+// and entity.ErrItemNotFound if there is no item with the old UUID, e.g. it was already remapped or deleted.
+func (repository *Repository) RemapItemUUID(ctx context.Context, from uuid.UUID, to uuid.UUID) error {
+	query := "update items set uuid=$2 where uuid=$1"
+	span, ctx := repository.setupTracingSpan(ctx, "remap-item-uuid", query)
+	defer span.Finish()
+	span.SetTag("item.UUID", from)
+	span.SetTag("item.newUUID", to)
+
+	tx, err := repository.pool.Begin(ctx)
+	if err != nil {
+		span.Fail(err)
+		return err
+	}
+	// Rollback is noop after commit
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, query, from, to)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		span.LogKV("event", "item exists")
+		return entity.ErrItemExists
+	}
+	if err != nil {
+		span.Fail(err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		span.LogKV("event", "item not found")
+		return entity.ErrItemNotFound
+	}
+	if _, err := tx.Exec(ctx, `update item_events_outbox set item_uuid=$2, payload=jsonb_set(payload, '{uuid}', to_jsonb($2::text))
+		where item_uuid=$1`, from, to); err != nil {
+		span.Fail(err)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		span.Fail(err)
+		return err
+	}
+	return nil
+}
+
+// DeleteDuplicateItem deletes item, which is a duplicate of original item, together with its unpublished events in outbox.
+// Original item joins cluster of the duplicate, if it isn't in a cluster yet.
+// Returns entity.ErrItemNotFound if there is no duplicate item, e.g. it was already deleted.
+func (repository *Repository) DeleteDuplicateItem(ctx context.Context, UUID uuid.UUID, originalUUID uuid.UUID) error {
+	query := "delete from items where uuid=$1 returning cluster_id"
+	span, ctx := repository.setupTracingSpan(ctx, "delete-duplicate-item", query)
+	defer span.Finish()
+	span.SetTag("item.UUID", UUID)
+	span.SetTag("item.originalUUID", originalUUID)
+
+	tx, err := repository.pool.Begin(ctx)
+	if err != nil {
+		span.Fail(err)
+		return err
+	}
+	// Rollback is noop after commit
+	defer tx.Rollback(ctx)
+
+	var clusterID *int64
+	err = tx.QueryRow(ctx, query, UUID).Scan(&clusterID)
+	if err == pgx.ErrNoRows {
+		span.LogKV("event", "item not found")
+		return entity.ErrItemNotFound
+	}
+	if err != nil {
+		span.Fail(err)
+		return err
+	}
+	if _, err := tx.Exec(ctx, "delete from item_events_outbox where item_uuid=$1", UUID); err != nil {
+		span.Fail(err)
+		return err
+	}
+	if clusterID != nil {
+		if _, err := tx.Exec(ctx, "update items set cluster_id=$2 where uuid=$1 and cluster_id is null", originalUUID, *clusterID); err != nil {
+			span.Fail(err)
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		span.Fail(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,700 @@
+// Package repositorytest provides conformance test suite for items repository implementations.
+// Every implementation must behave the same way, so that in-memory repository could replace PostgreSQL one in tests and local development.
+//
+// Suite is run from implementation package tests:
+//
+//	func TestConformance(t *testing.T) {
+//		repositorytest.Run(t, memory.New())
+//	}
+//
+// Tests don't clean up created items and use unique publication UUIDs, so the suite could be run against shared database.
+// Outbox test publishes all outbox events though, so the database must not be used by running outbox relay.
+package repositorytest
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tarick/naca-items/internal/entity"
+	"github.com/Tarick/naca-items/internal/graph/resolver"
+	"github.com/Tarick/naca-items/internal/outbox"
+	"github.com/Tarick/naca-items/internal/processor"
+	"github.com/Tarick/naca-items/pkg/itemevents"
+	"github.com/gofrs/uuid"
+)
+
+// Repository is the union of repository interfaces, required by processor, resolvers and outbox relay
+type Repository interface {
+	processor.ItemsRepository
+	resolver.ItemsRepository
+	outbox.Repository
+}
+
+// Run runs all conformance tests against repository
+func Run(t *testing.T, repository Repository) {
+	tests := []struct {
+		name string
+		test func(*testing.T, Repository)
+	}{
+		{"Create", testCreate},
+		{"CreateItems", testCreateItems},
+		{"Update", testUpdate},
+		{"UpdateIdentityFields", testUpdateIdentityFields},
+		{"UpsertNotification", testUpsertNotification},
+		{"CanonicalURLUniqueness", testCanonicalURLUniqueness},
+		{"ChangeItemState", testChangeItemState},
+		{"PublicationFilteringAndSortOrder", testPublicationFilteringAndSortOrder},
+		{"Pagination", testPagination},
+		{"Search", testSearch},
+		{"StoryClusters", testStoryClusters},
+		{"OutboxEvents", testOutboxEvents},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, repository)
+		})
+	}
+}
+
+// baseDate is rounded to microseconds, the precision of PostgreSQL timestamps
+var baseDate = time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+
+// newItem creates valid item of publication, published after baseDate with offset in hours
+func newItem(publicationUUID uuid.UUID, title string, hours int) *entity.Item {
+	itemCore := entity.NewItemCore()
+	itemCore.PublicationUUID = publicationUUID
+	itemCore.PublishedDate = baseDate.Add(time.Duration(hours) * time.Hour)
+	itemCore.Title = title
+	itemCore.Description = "Description of " + title
+	itemCore.Content = "Content of " + title
+	itemCore.URL = "https://example.com/" + uuid.Must(uuid.NewV4()).String()
+	itemCore.LanguageCode = "en"
+	return entity.NewFilledItem(itemCore)
+}
+
+func newPublicationUUID(t *testing.T) uuid.UUID {
+	t.Helper()
+	u, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// createItems creates number of items of publication, published hourly
+func createItems(t *testing.T, repository Repository, publicationUUID uuid.UUID, number int) []*entity.Item {
+	t.Helper()
+	items := make([]*entity.Item, number)
+	for i := range items {
+		items[i] = newItem(publicationUUID, fmt.Sprintf("Item number %d", i), i)
+		if err := repository.Create(context.Background(), items[i]); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+	return items
+}
+
+func assertItemEqual(t *testing.T, got *entity.Item, want *entity.Item) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("got nil item, want %s", want.UUID)
+	}
+	if got.UUID != want.UUID ||
+		got.PublicationUUID != want.PublicationUUID ||
+		!got.PublishedDate.Equal(want.PublishedDate) ||
+		got.Title != want.Title ||
+		got.Description != want.Description ||
+		got.Content != want.Content ||
+		got.URL != want.URL ||
+		got.LanguageCode != want.LanguageCode {
+		t.Errorf("got item %+v, want %+v", got.ItemCore, want.ItemCore)
+	}
+}
+
+func assertUUIDs(t *testing.T, got []*entity.Item, want []*entity.Item) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d items, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].UUID != want[i].UUID {
+			t.Errorf("item %d: got %s (%s), want %s (%s)", i, got[i].UUID, got[i].Title, want[i].UUID, want[i].Title)
+		}
+	}
+}
+
+func testCreate(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	item := newItem(newPublicationUUID(t), "Created item", 0)
+	if err := repository.Create(ctx, item); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	got, err := repository.GetItemByUUID(ctx, item.UUID)
+	if err != nil {
+		t.Fatalf("GetItemByUUID() failed: %v", err)
+	}
+	assertItemEqual(t, got, item)
+
+	if err := repository.Create(ctx, item); !errors.Is(err, entity.ErrItemExists) {
+		t.Errorf("second Create() returned %v, want %v", err, entity.ErrItemExists)
+	}
+
+	missing, err := repository.GetItemByUUID(ctx, uuid.Must(uuid.NewV4()))
+	if err != nil || missing != nil {
+		t.Errorf("GetItemByUUID() of missing item returned %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func testCreateItems(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	publicationUUID := newPublicationUUID(t)
+	existing := createItems(t, repository, publicationUUID, 1)[0]
+	fresh := newItem(publicationUUID, "Fresh item", 10)
+
+	created, err := repository.CreateItems(ctx, []*entity.Item{existing, fresh})
+	if err != nil {
+		t.Fatalf("CreateItems() failed: %v", err)
+	}
+	if len(created) != 1 || created[0] != fresh.UUID {
+		t.Errorf("CreateItems() created %v, want only %s", created, fresh.UUID)
+	}
+	got, err := repository.GetItemByUUID(ctx, fresh.UUID)
+	if err != nil {
+		t.Fatalf("GetItemByUUID() failed: %v", err)
+	}
+	assertItemEqual(t, got, fresh)
+}
+
+func testUpdate(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	publicationUUID := newPublicationUUID(t)
+	item := newItem(publicationUUID, "Upserted item", 0)
+	if err := repository.Update(ctx, item); err != nil {
+		t.Fatalf("Update() of missing item failed: %v", err)
+	}
+	got, err := repository.GetItemByUUID(ctx, item.UUID)
+	if err != nil {
+		t.Fatalf("GetItemByUUID() failed: %v", err)
+	}
+	assertItemEqual(t, got, item)
+
+	item.Description = "Corrected description"
+	item.Content = "Corrected content"
+	if err := repository.Update(ctx, item); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	got, err = repository.GetItemByUUID(ctx, item.UUID)
+	if err != nil {
+		t.Fatalf("GetItemByUUID() failed: %v", err)
+	}
+	assertItemEqual(t, got, item)
+}
+
+// testUpdateIdentityFields updates title and published date, which are not identity of items identified by GUID or URL
+func testUpdateIdentityFields(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	item := newItem(newPublicationUUID(t), "Original title", 0)
+	item.GUID = "urn:example:story:1"
+	item.UUID = entity.NewItemUUID(item.ItemCore, entity.GUIDIdentity{})
+	if err := repository.Create(ctx, item); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	item.Title = "Corrected title"
+	item.PublishedDate = item.PublishedDate.Add(90 * time.Minute)
+	if err := repository.Update(ctx, item); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	got, err := repository.GetItemByUUID(ctx, item.UUID)
+	if err != nil {
+		t.Fatalf("GetItemByUUID() failed: %v", err)
+	}
+	assertItemEqual(t, got, item)
+}
+
+// Notifier is implemented by repositories, which notify subscribers about item changes in process.
+// PostgreSQL repository notifies through database notifications, received by a separate listener.
+type Notifier interface {
+	Subscribe(handler func(*entity.ItemEvent))
+}
+
+// testUpsertNotification checks that item created by update is notified like created one
+func testUpsertNotification(t *testing.T, repository Repository) {
+	notifier, ok := repository.(Notifier)
+	if !ok {
+		t.Skip("repository doesn't notify subscribers in process")
+	}
+	ctx := context.Background()
+	var (
+		mu     sync.Mutex
+		events = map[uuid.UUID][]entity.ItemEventOperation{}
+	)
+	notifier.Subscribe(func(event *entity.ItemEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events[event.UUID] = append(events[event.UUID], event.Operation)
+	})
+	item := newItem(newPublicationUUID(t), "Upserted notified item", 0)
+	if err := repository.Update(ctx, item); err != nil {
+		t.Fatalf("Update() of missing item failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if got := events[item.UUID]; len(got) != 1 || got[0] != entity.ItemEventInsert {
+		t.Errorf("got events %v of item created by update, want single %s", got, entity.ItemEventInsert)
+	}
+}
+
+func testCanonicalURLUniqueness(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	publicationUUID := newPublicationUUID(t)
+	item := newItem(publicationUUID, "Original item", 0)
+	item.CanonicalURL = "https://example.com/story"
+	if err := repository.Create(ctx, item); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	got, err := repository.GetItemByUUID(ctx, item.UUID)
+	if err != nil {
+		t.Fatalf("GetItemByUUID() failed: %v", err)
+	}
+	if got.CanonicalURL != item.CanonicalURL {
+		t.Errorf("got canonical URL %q, want %q", got.CanonicalURL, item.CanonicalURL)
+	}
+
+	duplicate := newItem(publicationUUID, "Item with tracking parameters", 1)
+	duplicate.CanonicalURL = item.CanonicalURL
+	if err := repository.Create(ctx, duplicate); !errors.Is(err, entity.ErrItemExists) {
+		t.Errorf("Create() of item with stored canonical URL returned %v, want %v", err, entity.ErrItemExists)
+	}
+	created, err := repository.CreateItems(ctx, []*entity.Item{duplicate})
+	if err != nil {
+		t.Fatalf("CreateItems() failed: %v", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("CreateItems() created %v of item with stored canonical URL", created)
+	}
+	if err := repository.Update(ctx, duplicate); !errors.Is(err, entity.ErrItemExists) {
+		t.Errorf("Update() of item with stored canonical URL returned %v, want %v", err, entity.ErrItemExists)
+	}
+
+	// Canonical URL is unique within publication only
+	other := newItem(newPublicationUUID(t), "Item of another publication", 0)
+	other.CanonicalURL = item.CanonicalURL
+	if err := repository.Create(ctx, other); err != nil {
+		t.Errorf("Create() of item of another publication failed: %v", err)
+	}
+}
+
+func testChangeItemState(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	publicationUUID := newPublicationUUID(t)
+	items := createItems(t, repository, publicationUUID, 2)
+	disabled := items[0]
+
+	transition, err := repository.ChangeItemState(ctx, entity.NewItemStateTransition(disabled.UUID, entity.ItemStateDisabled, "Spam", "moderator"))
+	if err != nil {
+		t.Fatalf("ChangeItemState() failed: %v", err)
+	}
+	if transition == nil || transition.FromState != entity.ItemStateValid || transition.ToState != entity.ItemStateDisabled || transition.CreatedAt.IsZero() {
+		t.Fatalf("ChangeItemState() returned %+v, want recorded transition from valid to disabled", transition)
+	}
+
+	if got, err := repository.GetItemByUUID(ctx, disabled.UUID); err != nil || got != nil {
+		t.Errorf("GetItemByUUID() of disabled item returned %v, %v, want nil, nil", got, err)
+	}
+	if count, err := repository.CountItemsByPublicationUUID(ctx, publicationUUID); err != nil || count != 1 {
+		t.Errorf("CountItemsByPublicationUUID() returned %d, %v, want 1, nil", count, err)
+	}
+	publicationItems, err := repository.GetItemsByPublicationUUID(ctx, publicationUUID)
+	if err != nil {
+		t.Fatalf("GetItemsByPublicationUUID() failed: %v", err)
+	}
+	assertUUIDs(t, publicationItems, items[1:])
+	allItems, err := repository.GetItems(ctx)
+	if err != nil {
+		t.Fatalf("GetItems() failed: %v", err)
+	}
+	for _, item := range allItems {
+		if item.UUID == disabled.UUID {
+			t.Errorf("GetItems() returned disabled item")
+		}
+	}
+
+	transition, err = repository.ChangeItemState(ctx, entity.NewItemStateTransition(disabled.UUID, entity.ItemStateDisabled, "Spam", "moderator"))
+	if err != nil {
+		t.Fatalf("repeated ChangeItemState() failed: %v", err)
+	}
+	if transition == nil || transition.Changed() {
+		t.Errorf("repeated ChangeItemState() returned %+v, want unchanged transition", transition)
+	}
+
+	if _, err := repository.ChangeItemState(ctx, entity.NewItemStateTransition(disabled.UUID, entity.ItemStateValid, "Not a spam", "moderator")); err != nil {
+		t.Fatalf("ChangeItemState() failed: %v", err)
+	}
+	got, err := repository.GetItemByUUID(ctx, disabled.UUID)
+	if err != nil {
+		t.Fatalf("GetItemByUUID() failed: %v", err)
+	}
+	assertItemEqual(t, got, disabled)
+
+	transition, err = repository.ChangeItemState(ctx, entity.NewItemStateTransition(uuid.Must(uuid.NewV4()), entity.ItemStateDisabled, "Spam", "moderator"))
+	if err != nil || transition != nil {
+		t.Errorf("ChangeItemState() of missing item returned %v, %v, want nil, nil", transition, err)
+	}
+}
+
+func testPublicationFilteringAndSortOrder(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	publicationUUID := newPublicationUUID(t)
+	items := createItems(t, repository, publicationUUID, 3)
+	createItems(t, repository, newPublicationUUID(t), 2)
+
+	asc, err := repository.GetItemsByPublicationUUIDSortByPublishedDate(ctx, publicationUUID, true)
+	if err != nil {
+		t.Fatalf("GetItemsByPublicationUUIDSortByPublishedDate() failed: %v", err)
+	}
+	assertUUIDs(t, asc, items)
+
+	desc, err := repository.GetItemsByPublicationUUIDSortByPublishedDate(ctx, publicationUUID, false)
+	if err != nil {
+		t.Fatalf("GetItemsByPublicationUUIDSortByPublishedDate() failed: %v", err)
+	}
+	assertUUIDs(t, desc, []*entity.Item{items[2], items[1], items[0]})
+
+	if count, err := repository.CountItemsByPublicationUUID(ctx, publicationUUID); err != nil || count != 3 {
+		t.Errorf("CountItemsByPublicationUUID() returned %d, %v, want 3, nil", count, err)
+	}
+}
+
+func testPagination(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	publicationUUID := newPublicationUUID(t)
+	items := createItems(t, repository, publicationUUID, 5)
+	// Item with the same published date is ordered by UUID
+	sameDate := newItem(publicationUUID, "Item with the same date", 2)
+	if err := repository.Create(ctx, sameDate); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	sorted := []*entity.Item{items[0], items[1], items[2], items[3], items[4]}
+	if sameDate.UUID.String() < items[2].UUID.String() {
+		sorted = append(sorted[:2], append([]*entity.Item{sameDate}, sorted[2:]...)...)
+	} else {
+		sorted = append(sorted[:3], append([]*entity.Item{sameDate}, sorted[3:]...)...)
+	}
+	reversed := make([]*entity.Item, len(sorted))
+	for i := range sorted {
+		reversed[len(sorted)-1-i] = sorted[i]
+	}
+
+	tests := []struct {
+		name    string
+		sortAsc bool
+		after   *entity.Item
+		before  *entity.Item
+		limit   int
+		fromEnd bool
+		want    []*entity.Item
+	}{
+		{"all ascending", true, nil, nil, 0, false, sorted},
+		{"all descending", false, nil, nil, 0, false, reversed},
+		{"first page", true, nil, nil, 2, false, sorted[:2]},
+		{"after cursor", true, sorted[1], nil, 2, false, sorted[2:4]},
+		{"after cursor with the same date", true, sorted[2], nil, 0, false, sorted[3:]},
+		{"after cursor descending", false, reversed[1], nil, 2, false, reversed[2:4]},
+		{"last page", true, nil, nil, 2, true, sorted[4:]},
+		{"before cursor from end", true, nil, sorted[4], 2, true, sorted[2:4]},
+		{"between cursors", true, sorted[0], sorted[4], 0, false, sorted[1:4]},
+		{"before cursor descending", false, nil, reversed[3], 2, true, reversed[1:3]},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var after, before *entity.ItemCursor
+			if tt.after != nil {
+				after = entity.NewItemCursor(tt.after)
+			}
+			if tt.before != nil {
+				before = entity.NewItemCursor(tt.before)
+			}
+			got, err := repository.GetItemsPageByPublicationUUID(ctx, publicationUUID, tt.sortAsc, after, before, tt.limit, tt.fromEnd)
+			if err != nil {
+				t.Fatalf("GetItemsPageByPublicationUUID() failed: %v", err)
+			}
+			assertUUIDs(t, got, tt.want)
+		})
+	}
+}
+
+func testSearch(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	publicationUUID := newPublicationUUID(t)
+	inTitle := newItem(publicationUUID, "Zebra crossing is painted", 0)
+	inContent := newItem(publicationUUID, "Road works downtown", 1)
+	inContent.Content = "Workers painted a new zebra crossing near the school"
+	unrelated := newItem(publicationUUID, "Weather forecast", 2)
+	for _, item := range []*entity.Item{inTitle, inContent, unrelated} {
+		if err := repository.Create(ctx, item); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+
+	results, err := repository.SearchItems(ctx, &entity.ItemSearchQuery{Query: "zebra", PublicationUUID: publicationUUID, Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchItems() failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("SearchItems() found %d items, want 2", len(results))
+	}
+	if results[0].Item.UUID != inTitle.UUID || results[1].Item.UUID != inContent.UUID {
+		t.Errorf("SearchItems() returned %s, %s, want title match %s ranked above content match %s",
+			results[0].Item.Title, results[1].Item.Title, inTitle.Title, inContent.Title)
+	}
+	if results[0].Rank <= results[1].Rank {
+		t.Errorf("title match rank %f is not higher than content match rank %f", results[0].Rank, results[1].Rank)
+	}
+	if results[0].TitleHighlight != "<b>Zebra</b> crossing is painted" {
+		t.Errorf("title highlight is %q", results[0].TitleHighlight)
+	}
+
+	page, err := repository.SearchItems(ctx, &entity.ItemSearchQuery{Query: "zebra", PublicationUUID: publicationUUID, Offset: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchItems() failed: %v", err)
+	}
+	if len(page) != 1 || page[0].Item.UUID != inContent.UUID {
+		t.Errorf("SearchItems() with offset returned %d items, want only %s", len(page), inContent.Title)
+	}
+
+	other, err := repository.SearchItems(ctx, &entity.ItemSearchQuery{Query: "zebra", LanguageCode: "de", PublicationUUID: publicationUUID, Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchItems() failed: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("SearchItems() with other language found %d items, want 0", len(other))
+	}
+}
+
+// newFingerprint returns random fingerprint, so items of other tests are not its near-duplicates
+func newFingerprint(t *testing.T) uint64 {
+	t.Helper()
+	u, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return binary.BigEndian.Uint64(u.Bytes())
+}
+
+func testStoryClusters(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	fingerprint := newFingerprint(t)
+	original := newItem(newPublicationUUID(t), "Original story", 0)
+	original.Fingerprint = fingerprint
+	// The same story of another publication with slightly changed text
+	syndicated := newItem(newPublicationUUID(t), "Syndicated story", 2)
+	syndicated.Fingerprint = fingerprint ^ 0b101
+	unrelated := newItem(original.PublicationUUID, "Unrelated story", 1)
+	unrelated.Fingerprint = ^fingerprint
+	// Items are linked after creation, as processor does
+	for _, item := range []*entity.Item{original, unrelated} {
+		if err := repository.Create(ctx, item); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if clusterID, err := repository.LinkStoryCluster(ctx, item); err != nil || clusterID != 0 {
+			t.Errorf("LinkStoryCluster() of %s without near-duplicates returned %d, %v, want 0, nil", item.Title, clusterID, err)
+		}
+	}
+	if err := repository.Create(ctx, syndicated); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	clusterID, err := repository.LinkStoryCluster(ctx, syndicated)
+	if err != nil {
+		t.Fatalf("LinkStoryCluster() failed: %v", err)
+	}
+	if clusterID == 0 || syndicated.ClusterID != clusterID {
+		t.Fatalf("LinkStoryCluster() returned cluster %d and set %d, want the same non-zero cluster", clusterID, syndicated.ClusterID)
+	}
+	clusterItems, err := repository.GetItemsByClusterID(ctx, clusterID, 10)
+	if err != nil {
+		t.Fatalf("GetItemsByClusterID() failed: %v", err)
+	}
+	assertUUIDs(t, clusterItems, []*entity.Item{original, syndicated})
+	for _, item := range clusterItems {
+		if item.ClusterID != clusterID {
+			t.Errorf("item %s has cluster %d, want %d", item.Title, item.ClusterID, clusterID)
+		}
+	}
+	limited, err := repository.GetItemsByClusterID(ctx, clusterID, 1)
+	if err != nil {
+		t.Fatalf("GetItemsByClusterID() failed: %v", err)
+	}
+	assertUUIDs(t, limited, []*entity.Item{original})
+
+	// Another near-duplicate joins existing cluster
+	copied := newItem(newPublicationUUID(t), "Copied story", 3)
+	copied.Fingerprint = fingerprint ^ 0b11
+	if err := repository.Create(ctx, copied); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if got, err := repository.LinkStoryCluster(ctx, copied); err != nil || got != clusterID {
+		t.Errorf("LinkStoryCluster() of another near-duplicate returned %d, %v, want %d, nil", got, err, clusterID)
+	}
+
+	// Items published too far apart are not linked
+	late := newItem(newPublicationUUID(t), "Late story", 2+int(2*entity.NearDuplicateWindow/time.Hour))
+	late.Fingerprint = fingerprint
+	if err := repository.Create(ctx, late); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if got, err := repository.LinkStoryCluster(ctx, late); err != nil || got != 0 {
+		t.Errorf("LinkStoryCluster() of item out of window returned %d, %v, want 0, nil", got, err)
+	}
+
+	// Item, which text is changed, leaves cluster on relinking
+	copied.Fingerprint = fingerprint ^ 0xffffffff
+	if err := repository.Update(ctx, copied); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	if got, err := repository.LinkStoryCluster(ctx, copied); err != nil || got != 0 || copied.ClusterID != 0 {
+		t.Errorf("LinkStoryCluster() of changed item returned %d, %v and set %d, want 0, nil", got, err, copied.ClusterID)
+	}
+	clusterItems, err = repository.GetItemsByClusterID(ctx, clusterID, 10)
+	if err != nil {
+		t.Fatalf("GetItemsByClusterID() failed: %v", err)
+	}
+	assertUUIDs(t, clusterItems, []*entity.Item{original, syndicated})
+}
+
+// drainOutbox publishes all outbox events and returns the events of items
+func drainOutbox(t *testing.T, repository Repository, items ...*entity.Item) []*itemevents.EventEnvelope {
+	t.Helper()
+	itemUUIDs := map[uuid.UUID]bool{}
+	for _, item := range items {
+		itemUUIDs[item.UUID] = true
+	}
+	events := []*itemevents.EventEnvelope{}
+	publish := func(event *itemevents.EventEnvelope) error {
+		var body struct {
+			UUID uuid.UUID `json:"uuid"`
+		}
+		if err := decodeEventBody(event, &body); err != nil {
+			return err
+		}
+		if itemUUIDs[body.UUID] {
+			events = append(events, event)
+		}
+		return nil
+	}
+	const batchSize = 100
+	for {
+		published, err := repository.PublishOutboxEvents(context.Background(), batchSize, publish)
+		if err != nil {
+			t.Fatalf("PublishOutboxEvents() failed: %v", err)
+		}
+		if published < batchSize {
+			return events
+		}
+	}
+}
+
+func decodeEventBody(event *itemevents.EventEnvelope, body interface{}) error {
+	data, err := json.Marshal(event.Msg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, body)
+}
+
+func testOutboxEvents(t *testing.T, repository Repository) {
+	ctx := context.Background()
+	drainOutbox(t, repository)
+	publicationUUID := newPublicationUUID(t)
+	item := newItem(publicationUUID, "Item with events", 0)
+	if err := repository.Create(ctx, item); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	// Neither repeated creation nor repeated state change is an event
+	if err := repository.Create(ctx, item); !errors.Is(err, entity.ErrItemExists) {
+		t.Fatalf("second Create() returned %v, want %v", err, entity.ErrItemExists)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := repository.ChangeItemState(ctx, entity.NewItemStateTransition(item.UUID, entity.ItemStateDisabled, "Spam", "moderator")); err != nil {
+			t.Fatalf("ChangeItemState() failed: %v", err)
+		}
+	}
+	batched := newItem(publicationUUID, "Batched item with events", 1)
+	if _, err := repository.CreateItems(ctx, []*entity.Item{batched}); err != nil {
+		t.Fatalf("CreateItems() failed: %v", err)
+	}
+	// Update without changes is not an event
+	for i := 0; i < 2; i++ {
+		batched.Description = "Updated description of batched item"
+		if err := repository.Update(ctx, batched); err != nil {
+			t.Fatalf("Update() failed: %v", err)
+		}
+	}
+
+	events := drainOutbox(t, repository, item, batched)
+	wantTypes := []itemevents.EventType{itemevents.ItemCreatedType, itemevents.ItemStateChangedType, itemevents.ItemCreatedType, itemevents.ItemUpdatedType}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d", len(events), len(wantTypes))
+	}
+	for i, event := range events {
+		if event.Type != wantTypes[i] {
+			t.Errorf("event %d has type %d, want %d", i, event.Type, wantTypes[i])
+		}
+		if i > 0 && event.ID <= events[i-1].ID {
+			t.Errorf("event %d ID %d doesn't increase", i, event.ID)
+		}
+		if event.OccurredAt.IsZero() || event.Metadata == nil {
+			t.Errorf("event %d has no occurrence time or metadata: %+v", i, event)
+		}
+	}
+
+	var created itemevents.ItemBody
+	if err := decodeEventBody(events[0], &created); err != nil {
+		t.Fatalf("failure decoding event body: %v", err)
+	}
+	if created.UUID != item.UUID || created.PublicationUUID != publicationUUID || created.Title != item.Title || created.URL != item.URL {
+		t.Errorf("got created event body %+v, want fields of item %+v", created, item.ItemCore)
+	}
+	var stateChanged itemevents.ItemStateChangedBody
+	if err := decodeEventBody(events[1], &stateChanged); err != nil {
+		t.Fatalf("failure decoding event body: %v", err)
+	}
+	want := itemevents.ItemStateChangedBody{UUID: item.UUID, FromState: string(entity.ItemStateValid), ToState: string(entity.ItemStateDisabled), Reason: "Spam", Actor: "moderator"}
+	if stateChanged != want {
+		t.Errorf("got state changed event body %+v, want %+v", stateChanged, want)
+	}
+	var updated itemevents.ItemBody
+	if err := decodeEventBody(events[3], &updated); err != nil {
+		t.Fatalf("failure decoding event body: %v", err)
+	}
+	if updated.UUID != batched.UUID || updated.Description != batched.Description {
+		t.Errorf("got updated event body %+v, want fields of item %+v", updated, batched.ItemCore)
+	}
+
+	// Failed publishing keeps event in outbox
+	if err := repository.Update(ctx, batched); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	other := newItem(publicationUUID, "Item with failed event", 2)
+	if err := repository.Create(ctx, other); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	publishErr := errors.New("broker is unavailable")
+	_, err := repository.PublishOutboxEvents(ctx, 100, func(event *itemevents.EventEnvelope) error {
+		return publishErr
+	})
+	if !errors.Is(err, publishErr) {
+		t.Errorf("PublishOutboxEvents() returned %v, want %v", err, publishErr)
+	}
+	if events := drainOutbox(t, repository, other); len(events) != 1 {
+		t.Errorf("got %d events after failed publishing, want 1", len(events))
+	}
+}
@@ -0,0 +1,11 @@
+-- Write your migrate up statements here
+
+-- Used for keyset pagination of publication items sorted by published date
+CREATE INDEX items_publication_uuid_published_date_uuid_idx ON items (publication_uuid, published_date, uuid);
+
+---- create above / drop below ----
+
+DROP INDEX items_publication_uuid_published_date_uuid_idx;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
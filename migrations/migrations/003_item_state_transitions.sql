@@ -0,0 +1,20 @@
+-- Write your migrate up statements here
+
+create table item_state_transitions (
+  id bigserial PRIMARY KEY,
+  item_uuid uuid NOT NULL REFERENCES items(uuid) ON DELETE CASCADE,
+  from_state_id int NOT NULL REFERENCES item_state(id),
+  to_state_id int NOT NULL REFERENCES item_state(id),
+  reason TEXT NOT NULL,
+  actor TEXT NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX item_state_transitions_item_uuid_idx ON item_state_transitions (item_uuid, created_at);
+
+---- create above / drop below ----
+
+DROP TABLE "item_state_transitions";
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,46 @@
+-- Write your migrate up statements here
+
+-- Maps item language code to PostgreSQL text search configuration, 'simple' is used for languages without stemming support
+CREATE FUNCTION items_text_search_config(language_code varchar) RETURNS regconfig AS $$
+  SELECT CASE language_code
+    WHEN 'ar' THEN 'pg_catalog.arabic'
+    WHEN 'da' THEN 'pg_catalog.danish'
+    WHEN 'de' THEN 'pg_catalog.german'
+    WHEN 'en' THEN 'pg_catalog.english'
+    WHEN 'es' THEN 'pg_catalog.spanish'
+    WHEN 'fi' THEN 'pg_catalog.finnish'
+    WHEN 'fr' THEN 'pg_catalog.french'
+    WHEN 'ga' THEN 'pg_catalog.irish'
+    WHEN 'hu' THEN 'pg_catalog.hungarian'
+    WHEN 'id' THEN 'pg_catalog.indonesian'
+    WHEN 'it' THEN 'pg_catalog.italian'
+    WHEN 'lt' THEN 'pg_catalog.lithuanian'
+    WHEN 'ne' THEN 'pg_catalog.nepali'
+    WHEN 'nl' THEN 'pg_catalog.dutch'
+    WHEN 'no' THEN 'pg_catalog.norwegian'
+    WHEN 'pt' THEN 'pg_catalog.portuguese'
+    WHEN 'ro' THEN 'pg_catalog.romanian'
+    WHEN 'ru' THEN 'pg_catalog.russian'
+    WHEN 'sv' THEN 'pg_catalog.swedish'
+    WHEN 'ta' THEN 'pg_catalog.tamil'
+    WHEN 'tr' THEN 'pg_catalog.turkish'
+    ELSE 'pg_catalog.simple'
+  END::regconfig
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE items ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector(items_text_search_config(language_code), coalesce(title, '')), 'A') ||
+  setweight(to_tsvector(items_text_search_config(language_code), coalesce(description, '')), 'B') ||
+  setweight(to_tsvector(items_text_search_config(language_code), coalesce(content, '')), 'C')
+) STORED;
+
+CREATE INDEX items_search_vector_idx ON items USING GIN (search_vector);
+
+---- create above / drop below ----
+
+DROP INDEX items_search_vector_idx;
+ALTER TABLE items DROP COLUMN search_vector;
+DROP FUNCTION items_text_search_config;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,33 @@
+-- Write your migrate up statements here
+
+-- Items changes are published to 'item_events' channel, listeners use them to invalidate caches and notify clients
+CREATE OR REPLACE FUNCTION notify_item_event()
+RETURNS TRIGGER AS $$
+DECLARE
+  item RECORD;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    item := OLD;
+  ELSE
+    item := NEW;
+  END IF;
+  PERFORM pg_notify('item_events', json_build_object(
+    'op', lower(TG_OP),
+    'uuid', item.uuid,
+    'publication_uuid', item.publication_uuid,
+    'language_code', item.language_code
+  )::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER items_notify_event AFTER INSERT OR UPDATE OR DELETE ON "items" FOR EACH ROW EXECUTE PROCEDURE notify_item_event();
+
+---- create above / drop below ----
+
+DROP TRIGGER items_notify_event ON "items";
+
+DROP FUNCTION notify_item_event;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
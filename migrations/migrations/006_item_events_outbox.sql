@@ -0,0 +1,19 @@
+-- Write your migrate up statements here
+
+-- Transactional outbox of items domain events, rows are written together with items changes
+-- and deleted by the worker relay once published
+create table item_events_outbox (
+  id bigserial PRIMARY KEY,
+  event_type int NOT NULL,
+  item_uuid uuid NOT NULL,
+  payload jsonb NOT NULL,
+  metadata jsonb NOT NULL DEFAULT '{}',
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+---- create above / drop below ----
+
+DROP TABLE "item_events_outbox";
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
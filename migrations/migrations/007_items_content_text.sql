@@ -0,0 +1,11 @@
+-- Write your migrate up statements here
+
+-- Plain text version of item content, produced by worker processing stages
+ALTER TABLE items ADD COLUMN content_text TEXT NOT NULL DEFAULT '';
+
+---- create above / drop below ----
+
+ALTER TABLE items DROP COLUMN content_text;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
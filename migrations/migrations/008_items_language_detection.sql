@@ -0,0 +1,13 @@
+-- Write your migrate up statements here
+
+-- Language item was received with and language found by worker language detection, language_code is the effective one
+ALTER TABLE items ADD COLUMN declared_language_code varchar(2) NOT NULL DEFAULT '';
+ALTER TABLE items ADD COLUMN detected_language_code varchar(2) NOT NULL DEFAULT '';
+
+---- create above / drop below ----
+
+ALTER TABLE items DROP COLUMN detected_language_code;
+ALTER TABLE items DROP COLUMN declared_language_code;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
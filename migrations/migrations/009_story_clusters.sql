@@ -0,0 +1,36 @@
+-- Write your migrate up statements here
+
+-- Number of different bits in SimHash fingerprints
+CREATE FUNCTION items_fingerprint_distance(a bigint, b bigint) RETURNS int AS $$
+  SELECT length(replace((a # b)::bit(64)::text, '0', ''))
+$$ LANGUAGE SQL IMMUTABLE STRICT;
+
+-- Story cluster links near-duplicate items, e.g. the same story syndicated by several publications
+create table story_clusters (
+  id bigserial PRIMARY KEY,
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE items ADD COLUMN fingerprint bigint;
+ALTER TABLE items ADD COLUMN cluster_id bigint REFERENCES story_clusters(id) ON DELETE SET NULL;
+
+CREATE INDEX items_cluster_id_idx ON items (cluster_id) WHERE cluster_id IS NOT NULL;
+-- Fingerprints within 7 different bits share at least one of 8 8-bit bands, bands with published date are used to find near-duplicate candidates
+CREATE INDEX items_fingerprint_band0_idx ON items (((fingerprint >> 56) & 255), published_date) WHERE fingerprint IS NOT NULL;
+CREATE INDEX items_fingerprint_band1_idx ON items (((fingerprint >> 48) & 255), published_date) WHERE fingerprint IS NOT NULL;
+CREATE INDEX items_fingerprint_band2_idx ON items (((fingerprint >> 40) & 255), published_date) WHERE fingerprint IS NOT NULL;
+CREATE INDEX items_fingerprint_band3_idx ON items (((fingerprint >> 32) & 255), published_date) WHERE fingerprint IS NOT NULL;
+CREATE INDEX items_fingerprint_band4_idx ON items (((fingerprint >> 24) & 255), published_date) WHERE fingerprint IS NOT NULL;
+CREATE INDEX items_fingerprint_band5_idx ON items (((fingerprint >> 16) & 255), published_date) WHERE fingerprint IS NOT NULL;
+CREATE INDEX items_fingerprint_band6_idx ON items (((fingerprint >> 8) & 255), published_date) WHERE fingerprint IS NOT NULL;
+CREATE INDEX items_fingerprint_band7_idx ON items (((fingerprint >> 0) & 255), published_date) WHERE fingerprint IS NOT NULL;
+
+---- create above / drop below ----
+
+ALTER TABLE items DROP COLUMN cluster_id;
+ALTER TABLE items DROP COLUMN fingerprint;
+DROP TABLE story_clusters;
+DROP FUNCTION items_fingerprint_distance(bigint, bigint);
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
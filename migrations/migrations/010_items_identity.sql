@@ -0,0 +1,19 @@
+-- Write your migrate up statements here
+
+-- Feed entry identifier, used by guid identity strategy
+ALTER TABLE items ADD COLUMN guid TEXT NOT NULL DEFAULT '';
+
+-- Item UUID is remapped, when publication identity strategy is changed, transitions follow it
+ALTER TABLE item_state_transitions DROP CONSTRAINT item_state_transitions_item_uuid_fkey;
+ALTER TABLE item_state_transitions ADD CONSTRAINT item_state_transitions_item_uuid_fkey
+  FOREIGN KEY (item_uuid) REFERENCES items(uuid) ON DELETE CASCADE ON UPDATE CASCADE;
+
+---- create above / drop below ----
+
+ALTER TABLE item_state_transitions DROP CONSTRAINT item_state_transitions_item_uuid_fkey;
+ALTER TABLE item_state_transitions ADD CONSTRAINT item_state_transitions_item_uuid_fkey
+  FOREIGN KEY (item_uuid) REFERENCES items(uuid) ON DELETE CASCADE;
+ALTER TABLE items DROP COLUMN guid;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,15 @@
+-- Write your migrate up statements here
+
+-- Canonical URL is produced by item processing, original URL is kept. Empty if URL couldn't be canonicalized.
+-- Existing items get canonical URL when they are updated
+ALTER TABLE items ADD COLUMN canonical_url TEXT NOT NULL DEFAULT '';
+-- Items of publication are deduplicated by canonical URL
+CREATE UNIQUE INDEX items_publication_uuid_canonical_url_idx ON items (publication_uuid, canonical_url) WHERE canonical_url <> '';
+
+---- create above / drop below ----
+
+DROP INDEX items_publication_uuid_canonical_url_idx;
+ALTER TABLE items DROP COLUMN canonical_url;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,12 @@
+-- Write your migrate up statements here
+
+-- Relay claims events until the time, so they are published outside of transaction and aren't picked by other relays.
+-- Events of failed relay are claimed again once the time passes
+ALTER TABLE item_events_outbox ADD COLUMN claimed_until timestamptz;
+
+---- create above / drop below ----
+
+ALTER TABLE item_events_outbox DROP COLUMN claimed_until;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
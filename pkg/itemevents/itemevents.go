@@ -0,0 +1,59 @@
+// Package itemevents defines domain events of items, published by items worker to NSQ.
+// Events are published at least once, consumers should skip events with already handled ID.
+package itemevents
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// ItemCreatedType is the type of event, published when new item is added
+	ItemCreatedType EventType = iota
+	// ItemUpdatedType is the type of event, published when fields of existing item are changed
+	ItemUpdatedType
+	// ItemStateChangedType is the type of event, published when item is disabled or restored
+	ItemStateChangedType
+)
+
+// EventType defines types of events
+type EventType uint
+
+// EventBody is the payload of event, one of ItemBody or ItemStateChangedBody depending on Type.
+// Use json.RawMessage to delay unmarshalling till Type is known.
+type EventBody interface{}
+
+// EventEnvelope has the same shape as items messages envelope: event type, metadata (e.g. opentracing) and Msg as event body.
+// ID increases with every event, it could be used for deduplication.
+type EventEnvelope struct {
+	ID         int64             `json:"id"`
+	Type       EventType         `json:"type,int"`
+	Metadata   map[string]string `json:"metadata,string"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Msg        EventBody
+}
+
+// ItemBody is the body of ItemCreated and ItemUpdated events with the current item fields
+type ItemBody struct {
+	UUID            uuid.UUID `json:"uuid"`
+	PublicationUUID uuid.UUID `json:"publication_uuid"`
+	PublishedDate   time.Time `json:"published_date"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description,omitempty"`
+	Content         string    `json:"content,omitempty"`
+	URL             string    `json:"url"`
+	LanguageCode    string    `json:"language_code"`
+	ContentText     string    `json:"content_text,omitempty"`
+	GUID            string    `json:"guid,omitempty"`
+	CanonicalURL    string    `json:"canonical_url,omitempty"`
+}
+
+// ItemStateChangedBody is the body of ItemStateChanged event
+type ItemStateChangedBody struct {
+	UUID      uuid.UUID `json:"uuid"`
+	FromState string    `json:"from_state"`
+	ToState   string    `json:"to_state"`
+	Reason    string    `json:"reason"`
+	Actor     string    `json:"actor"`
+}
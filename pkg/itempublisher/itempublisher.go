@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/Tarick/naca-items/internal/entity"
 	"github.com/Tarick/naca-items/internal/messaging/nsqclient/producer"
 	"github.com/Tarick/naca-items/internal/processor"
 	"github.com/gofrs/uuid"
@@ -33,7 +34,83 @@ func (p *messagePublisher) PublishNewItem(
 	if err != nil {
 		return err
 	}
+	return p.publish(message)
+}
+
+// NewItem defines item fields for batch publishing
+type NewItem struct {
+	PublicationUUID uuid.UUID
+	Title           string
+	Description     string
+	Content         string
+	URL             string
+	// LanguageCode could be empty, then it is detected by items processing
+	LanguageCode  string
+	PublishedDate time.Time
+	// GUID is the feed entry identifier, used by guid identity strategy
+	GUID string
+}
+
+// PublishNewItems publishes batch of new items in a single message. All items must be valid.
+func (p *messagePublisher) PublishNewItems(metadata map[string]string, items []NewItem) error {
+	itemCores := make([]*entity.ItemCore, len(items))
+	for i, item := range items {
+		itemCore := entity.NewItemCore()
+		itemCore.PublicationUUID = item.PublicationUUID
+		itemCore.PublishedDate = item.PublishedDate
+		itemCore.Title = item.Title
+		itemCore.Description = item.Description
+		itemCore.Content = item.Content
+		itemCore.URL = item.URL
+		itemCore.LanguageCode = item.LanguageCode
+		itemCore.GUID = item.GUID
+		itemCores[i] = itemCore
+	}
+	message, err := processor.NewItemsBatchMessageEnvelope(metadata, itemCores)
+	if err != nil {
+		return err
+	}
+	return p.publish(message)
+}
+
+// PublishUpdateItem publishes updated version of already published item.
+// Item is identified by publication identity strategy, e.g. by publicationUUID, title and publishedDate, the rest of fields replace stored ones.
+func (p *messagePublisher) PublishUpdateItem(
+	metadata map[string]string,
+	publicationUUID uuid.UUID,
+	title string,
+	description string,
+	content string,
+	url string,
+	languageCode string,
+	publishedDate time.Time) error {
+
+	message, err := processor.UpdateItemMessageEnvelope(metadata, publicationUUID, title, description, content, url, languageCode, publishedDate)
+	if err != nil {
+		return err
+	}
+	return p.publish(message)
+}
+
+// PublishDisableItem publishes request to disable (hide) item with the reason and the actor, requesting it
+func (p *messagePublisher) PublishDisableItem(metadata map[string]string, itemUUID uuid.UUID, reason string, actor string) error {
+	message, err := processor.DisableItemMessageEnvelope(metadata, itemUUID, reason, actor)
+	if err != nil {
+		return err
+	}
+	return p.publish(message)
+}
+
+// PublishEnableItem publishes request to restore disabled item with the reason and the actor, requesting it
+func (p *messagePublisher) PublishEnableItem(metadata map[string]string, itemUUID uuid.UUID, reason string, actor string) error {
+	message, err := processor.EnableItemMessageEnvelope(metadata, itemUUID, reason, actor)
+	if err != nil {
+		return err
+	}
+	return p.publish(message)
+}
 
+func (p *messagePublisher) publish(message *processor.MessageEnvelope) error {
 	bytes, err := json.Marshal(message)
 	if err != nil {
 		return err